@@ -16,6 +16,16 @@ func CopyField(f gopium.Field) gopium.Field {
 		nf.Comment = make([]string, len(f.Comment), cap(f.Comment))
 		copy(nf.Comment, f.Comment)
 	}
+	// check that field archs exists
+	if f.Archs != nil {
+		nf.Archs = make([]gopium.Arch, len(f.Archs), cap(f.Archs))
+		copy(nf.Archs, f.Archs)
+	}
+	// check that field affinities exists
+	if f.Affinities != nil {
+		nf.Affinities = make([]gopium.Affinity, len(f.Affinities), cap(f.Affinities))
+		copy(nf.Affinities, f.Affinities)
+	}
 	return nf
 }
 
@@ -45,6 +45,52 @@ func TestCopyField(t *testing.T) {
 				Comment:  []string{"test-com-1", "test-com-2"},
 			},
 		},
+		"non empty field with archs should be copied to same field": {
+			o: gopium.Field{
+				Name: "test",
+				Type: "type",
+				Archs: []gopium.Arch{
+					{
+						Target: "gc/386",
+						Size:   4,
+						Align:  4,
+					},
+				},
+			},
+			r: gopium.Field{
+				Name: "test",
+				Type: "type",
+				Archs: []gopium.Arch{
+					{
+						Target: "gc/386",
+						Size:   4,
+						Align:  4,
+					},
+				},
+			},
+		},
+		"non empty field with affinities should be copied to same field": {
+			o: gopium.Field{
+				Name: "test",
+				Type: "type",
+				Affinities: []gopium.Affinity{
+					{
+						Name:  "test2",
+						Count: 2,
+					},
+				},
+			},
+			r: gopium.Field{
+				Name: "test",
+				Type: "type",
+				Affinities: []gopium.Affinity{
+					{
+						Name:  "test2",
+						Count: 2,
+					},
+				},
+			},
+		},
 	}
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
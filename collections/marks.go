@@ -0,0 +1,135 @@
+package collections
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of supported gopium field tag markers
+const (
+	MarkCold    = "cold"
+	MarkIsolate = "isolate"
+	MarkBitset  = "bitset"
+	MarkOrder   = "order"
+	MarkPin     = "pin"
+	MarkHot     = "hot"
+	MarkWarm    = "warm"
+	MarkHeat    = "heat"
+)
+
+// marks contains all supported gopium
+// field tag markers names
+var marks = map[string]bool{
+	MarkCold:    true,
+	MarkIsolate: true,
+	MarkBitset:  true,
+	MarkOrder:   true,
+	MarkPin:     true,
+	MarkHot:     true,
+	MarkWarm:    true,
+	MarkHeat:    true,
+}
+
+// Marks splits gopium field tag value
+// into markers tokens and rest tokens,
+// markers are `;` separated tokens
+// either in `name` or `name:value` format,
+// e.g. `gopium:"cold;group:def;stg,stg"`
+// is split to [cold] and [group:def stg,stg]
+func Marks(tag string) ([]string, []string) {
+	// trim all excess separators
+	tag = strings.Trim(tag, ";")
+	// go through all tag tokens
+	var mtokens, rtokens []string
+	for _, token := range strings.Split(tag, ";") {
+		// grab marker name from token
+		name := strings.SplitN(token, ":", 2)[0]
+		if marks[name] {
+			mtokens = append(mtokens, token)
+			continue
+		}
+		rtokens = append(rtokens, token)
+	}
+	return mtokens, rtokens
+}
+
+// Marker looks up gopium marker by name
+// inside provided full field tag
+// and returns marker value if any
+func Marker(tag string, name string) (string, bool) {
+	// grab the gopium field tag
+	gtag, ok := reflect.StructTag(tag).Lookup(gopium.NAME)
+	if !ok {
+		return "", false
+	}
+	// go through all tag markers
+	mtokens, _ := Marks(gtag)
+	for _, token := range mtokens {
+		if parts := strings.SplitN(token, ":", 2); parts[0] == name {
+			if len(parts) == 2 {
+				return parts[1], true
+			}
+			return "", true
+		}
+	}
+	return "", false
+}
+
+// Remark replaces gopium marker by name
+// inside provided full field tag with provided
+// marker token placed in front of gopium tag,
+// empty marker token just removes the marker
+// and gopium tag itself if it becomes empty
+func Remark(tag string, name string, token string) string {
+	gtag, ok := reflect.StructTag(tag).Lookup(gopium.NAME)
+	// collect all gopium tag tokens
+	// except previous marker tokens
+	tokens := make([]string, 0, 2)
+	if token != "" {
+		tokens = append(tokens, token)
+	}
+	if ok {
+		for _, tok := range strings.Split(strings.Trim(gtag, ";"), ";") {
+			if tok == "" || strings.SplitN(tok, ":", 2)[0] == name {
+				continue
+			}
+			tokens = append(tokens, tok)
+		}
+	}
+	otag := fmt.Sprintf(`%s:"%s"`, gopium.NAME, gtag)
+	ntag := fmt.Sprintf(`%s:"%s"`, gopium.NAME, strings.Join(tokens, ";"))
+	switch {
+	case ok && len(tokens) > 0:
+		return strings.Replace(tag, otag, ntag, 1)
+	case ok && strings.Contains(tag, " "+otag):
+		return strings.TrimSpace(strings.Replace(tag, " "+otag, "", 1))
+	case ok:
+		return strings.TrimSpace(strings.Replace(tag, otag, "", 1))
+	case len(tokens) == 0:
+		return tag
+	case tag != "":
+		return tag + " " + ntag
+	default:
+		return ntag
+	}
+}
+
+// Cold returns cold fields companion
+// struct type name and companion
+// pointer field name for provided struct name
+func Cold(name string) (string, string) {
+	return name + "Cold", "cold"
+}
+
+// Bitset returns bitset flags field name
+// and flag setter method name
+// for provided bool field name
+func Bitset(name string) (string, string) {
+	r, size := utf8.DecodeRuneInString(name)
+	return "flags", "set" + string(unicode.ToUpper(r)) + name[size:]
+}
@@ -0,0 +1,225 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarks(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		tag     string
+		mtokens []string
+		rtokens []string
+	}{
+		"empty tag should be split to empty rest token": {
+			rtokens: []string{""},
+		},
+		"tag without markers should be split to rest tokens": {
+			tag:     "group:def;memory_pack,filter_pads",
+			rtokens: []string{"group:def", "memory_pack,filter_pads"},
+		},
+		"tag with only markers should be split to markers tokens": {
+			tag:     "cold;",
+			mtokens: []string{"cold"},
+		},
+		"tag with markers should be split to markers and rest tokens": {
+			tag:     ";cold;group:def;memory_pack,filter_pads",
+			mtokens: []string{"cold"},
+			rtokens: []string{"group:def", "memory_pack,filter_pads"},
+		},
+		"tag with marker values should be split to markers and rest tokens": {
+			tag:     "memory_pack;cold:test",
+			mtokens: []string{"cold:test"},
+			rtokens: []string{"memory_pack"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			mtokens, rtokens := Marks(tcase.tag)
+			// check
+			if !reflect.DeepEqual(mtokens, tcase.mtokens) {
+				t.Errorf("actual %v doesn't equal to %v", mtokens, tcase.mtokens)
+			}
+			if !reflect.DeepEqual(rtokens, tcase.rtokens) {
+				t.Errorf("actual %v doesn't equal to %v", rtokens, tcase.rtokens)
+			}
+		})
+	}
+}
+
+func TestMarker(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		tag  string
+		name string
+		val  string
+		ok   bool
+	}{
+		"empty tag should have no marker": {
+			name: MarkCold,
+		},
+		"tag without gopium tag should have no marker": {
+			tag:  `json:"cold"`,
+			name: MarkCold,
+		},
+		"tag without marker should have no marker": {
+			tag:  `gopium:"memory_pack"`,
+			name: MarkCold,
+		},
+		"tag with marker should have marker": {
+			tag:  `json:"test" gopium:"cold;memory_pack"`,
+			name: MarkCold,
+			ok:   true,
+		},
+		"tag with marker value should have marker with value": {
+			tag:  `gopium:"group:def;cold:test;memory_pack"`,
+			name: MarkCold,
+			val:  "test",
+			ok:   true,
+		},
+		"tag with several markers should have marker with value": {
+			tag:  `gopium:"cold;isolate:cnt;memory_pack"`,
+			name: MarkIsolate,
+			val:  "cnt",
+			ok:   true,
+		},
+		"tag with order marker should have marker with value": {
+			tag:  `gopium:"order:2;cold;group:def;memory_pack"`,
+			name: MarkOrder,
+			val:  "2",
+			ok:   true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			val, ok := Marker(tcase.tag, tcase.name)
+			// check
+			if !reflect.DeepEqual(val, tcase.val) {
+				t.Errorf("actual %v doesn't equal to %v", val, tcase.val)
+			}
+			if !reflect.DeepEqual(ok, tcase.ok) {
+				t.Errorf("actual %v doesn't equal to %v", ok, tcase.ok)
+			}
+		})
+	}
+}
+
+func TestCold(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		name  string
+		ctype string
+		cname string
+	}{
+		"exported struct should have exported companion": {
+			name:  "Session",
+			ctype: "SessionCold",
+			cname: "cold",
+		},
+		"unexported struct should have unexported companion": {
+			name:  "session",
+			ctype: "sessionCold",
+			cname: "cold",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			ctype, cname := Cold(tcase.name)
+			// check
+			if !reflect.DeepEqual(ctype, tcase.ctype) {
+				t.Errorf("actual %v doesn't equal to %v", ctype, tcase.ctype)
+			}
+			if !reflect.DeepEqual(cname, tcase.cname) {
+				t.Errorf("actual %v doesn't equal to %v", cname, tcase.cname)
+			}
+		})
+	}
+}
+
+func TestBitset(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		name   string
+		fname  string
+		setter string
+	}{
+		"single letter field should have expected setter": {
+			name:   "a",
+			fname:  "flags",
+			setter: "setA",
+		},
+		"camel case field should have expected setter": {
+			name:   "isReady",
+			fname:  "flags",
+			setter: "setIsReady",
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			fname, setter := Bitset(tcase.name)
+			// check
+			if !reflect.DeepEqual(fname, tcase.fname) {
+				t.Errorf("actual %v doesn't equal to %v", fname, tcase.fname)
+			}
+			if !reflect.DeepEqual(setter, tcase.setter) {
+				t.Errorf("actual %v doesn't equal to %v", setter, tcase.setter)
+			}
+		})
+	}
+}
+
+func TestRemark(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		tag   string
+		name  string
+		token string
+		r     string
+	}{
+		"empty tag should be remarked to marker tag": {
+			name:  MarkPin,
+			token: "pin:first",
+			r:     `gopium:"pin:first"`,
+		},
+		"empty tag should be remarked to empty tag without token": {
+			name: MarkPin,
+		},
+		"tag without gopium tag should be remarked to expected tag": {
+			tag:   `json:"a"`,
+			name:  MarkOrder,
+			token: "order:1",
+			r:     `json:"a" gopium:"order:1"`,
+		},
+		"tag with marker should be remarked to expected tag": {
+			tag:   `json:"a" gopium:"cold;order:1;memory_pack" xml:"a"`,
+			name:  MarkOrder,
+			token: "order:2",
+			r:     `json:"a" gopium:"order:2;cold;memory_pack" xml:"a"`,
+		},
+		"tag with marker should be remarked to expected tag without token": {
+			tag:  `json:"a" gopium:"pin;memory_pack"`,
+			name: MarkPin,
+			r:    `json:"a" gopium:"memory_pack"`,
+		},
+		"tag with only marker should be remarked to expected tag without token": {
+			tag:  `json:"a" gopium:"pin" xml:"a"`,
+			name: MarkPin,
+			r:    `json:"a" xml:"a"`,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := Remark(tcase.tag, tcase.name, tcase.token)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to %v", r, tcase.r)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package collections
+
+import "sort"
+
+// sizeClasses contains go runtime allocator
+// small objects size classes in bytes
+// note: copied from `runtime/sizeclasses.go`
+var sizeClasses = []int64{
+	8, 16, 24, 32, 48, 64, 80, 96, 112, 128,
+	144, 160, 176, 192, 208, 224, 240, 256, 288, 320,
+	352, 384, 416, 448, 480, 512, 576, 640, 704, 768,
+	896, 1024, 1152, 1280, 1408, 1536, 1792, 2048, 2304, 2688,
+	3072, 3200, 3456, 4096, 4864, 5376, 6144, 6528, 6784, 6912,
+	8192, 9472, 9728, 10240, 10880, 12288, 13568, 14336, 16384, 18432,
+	19072, 20480, 21760, 24576, 27264, 28672, 32768,
+}
+
+// pageSize defines go runtime allocator page size
+// that large objects sizes are rounded up to
+const pageSize = 8192
+
+// SizeClass returns go runtime allocator size class
+// that heap object of provided size is rounded up to,
+// zero sized objects occupy no memory and large
+// objects are rounded up to allocator page size
+func SizeClass(size int64) int64 {
+	if size <= 0 {
+		return 0
+	}
+	if size > sizeClasses[len(sizeClasses)-1] {
+		return Align(size, pageSize)
+	}
+	i := sort.Search(len(sizeClasses), func(i int) bool {
+		return sizeClasses[i] >= size
+	})
+	return sizeClasses[i]
+}
@@ -0,0 +1,53 @@
+package collections
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSizeClass(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		size  int64
+		class int64
+	}{
+		"zero size should return zero size class": {
+			size:  0,
+			class: 0,
+		},
+		"negative size should return zero size class": {
+			size:  -8,
+			class: 0,
+		},
+		"small size should return the smallest size class": {
+			size:  1,
+			class: 8,
+		},
+		"exact size class size should return the same size class": {
+			size:  48,
+			class: 48,
+		},
+		"size between size classes should return next size class": {
+			size:  65,
+			class: 80,
+		},
+		"the biggest small size should return the biggest size class": {
+			size:  32768,
+			class: 32768,
+		},
+		"large size should return size rounded to page size": {
+			size:  32769,
+			class: 40960,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			class := SizeClass(tcase.size)
+			// check
+			if !reflect.DeepEqual(class, tcase.class) {
+				t.Errorf("actual %v doesn't equal to expected %v", class, tcase.class)
+			}
+		})
+	}
+}
@@ -253,6 +253,8 @@ namespace settings {
 		readonly e?: string[]
 		readonly f?: string[]
 		// gopium walker fields
+		readonly o?: string
+		readonly y?: string
 		readonly d?: boolean
 		readonly b?: boolean
 		// gopium printer fields
@@ -295,6 +297,8 @@ namespace settings {
 				e: root.get<string[]>('packageBuildEnvs'),
 				f: root.get<string[]>('packageBuildFlags'),
 				// gopium walker vars
+				o: root.get<string>('walkerProfile'),
+				y: root.get<string>('walkerProfileSampleType'),
 				d: root.get<boolean>('walkerDeep'),
 				b: root.get<boolean>('walkerBackref'),
 				// gopium printer vars
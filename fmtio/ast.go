@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"reflect"
 	"sort"
 	"strconv"
 
@@ -234,6 +235,17 @@ func tagsync(ts *ast.TypeSpec, st gopium.Struct) error {
 				Kind:  token.STRING,
 				Value: fmt.Sprintf("`%s`", f.Tag),
 			}
+			continue
+		}
+		// in case gopium field tag has been
+		// removed remove ast tag as well
+		if field.Tag == nil {
+			continue
+		}
+		if tag, err := strconv.Unquote(field.Tag.Value); err == nil {
+			if _, ok := reflect.StructTag(tag).Lookup(gopium.NAME); ok {
+				field.Tag = nil
+			}
 		}
 	}
 	return nil
@@ -171,6 +171,87 @@ test struct {
 	int64
 	float32 embedded
 }
+`),
+		},
+		"struct with removed gopium tags should be sorted and untagged correctly": {
+			fmt: FSPT,
+			ts: &ast.TypeSpec{
+				Name: &ast.Ident{
+					Name: "test",
+				},
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "b",
+									},
+								},
+								Type: &ast.Ident{
+									Name: "string",
+								},
+								Tag: &ast.BasicLit{
+									Kind:  token.STRING,
+									Value: "`gopium:\"order:1\"`",
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "a",
+									},
+								},
+								Type: &ast.Ident{
+									Name: "string",
+								},
+								Tag: &ast.BasicLit{
+									Kind:  token.STRING,
+									Value: "`json:\"a\" gopium:\"order:0\"`",
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "c",
+									},
+								},
+								Type: &ast.Ident{
+									Name: "string",
+								},
+								Tag: &ast.BasicLit{
+									Kind:  token.STRING,
+									Value: "`json:\"c\"`",
+								},
+							},
+						},
+					},
+				},
+			},
+			st: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Type: "string",
+						Tag:  `json:"a"`,
+					},
+					{
+						Name: "b",
+						Type: "string",
+					},
+					{
+						Name: "c",
+						Type: "string",
+					},
+				},
+			},
+			r: []byte(`
+test struct {
+	a string 'json:"a"'
+	b string
+	c string 'json:"c"'
+}
 `),
 		},
 		"struct with excess paddings and fields should be filtered and sorted": {
@@ -6,6 +6,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"sort"
 	"strings"
 	"sync"
@@ -19,18 +20,34 @@ import (
 )
 
 // UFFN implements apply and combines:
+// - bitset helper
+// - split helper
 // - ufmt with fmtio FSPT helper
 // - filter helper
 // - note helper
-var UFFN = combine(
-	ufmt(walk, fmtio.FSPT),
-	filter(walk),
-	note(
+var UFFN = bitset(
+	walk,
+	&typepkg.ParserXToolPackagesAst{
+		ModeAst: parser.ParseComments | parser.AllErrors,
+	},
+	fmtio.Gofmt{},
+	split(
 		walk,
 		&typepkg.ParserXToolPackagesAst{
 			ModeAst: parser.ParseComments | parser.AllErrors,
 		},
 		fmtio.Gofmt{},
+		combine(
+			ufmt(walk, fmtio.FSPT),
+			filter(walk),
+			note(
+				walk,
+				&typepkg.ParserXToolPackagesAst{
+					ModeAst: parser.ParseComments | parser.AllErrors,
+				},
+				fmtio.Gofmt{},
+			),
+		),
 	),
 )
 
@@ -40,6 +57,7 @@ func combine(funcs ...gopium.Apply) gopium.Apply {
 	return func(
 		ctx context.Context,
 		pkg *ast.Package,
+		tpkg *types.Package,
 		loc gopium.Locator,
 		c gopium.Categorized,
 	) (rpkg *ast.Package, err error) {
@@ -47,7 +65,7 @@ func combine(funcs ...gopium.Apply) gopium.Apply {
 		// could be skipped
 		// we could skip err check here
 		// as cat alway returns nil
-		pkg, _ = cat(ctx, pkg, loc, c)
+		pkg, _ = cat(ctx, pkg, tpkg, loc, c)
 		// go through all provided funcs
 		for _, fun := range funcs {
 			// manage context actions
@@ -59,7 +77,7 @@ func combine(funcs ...gopium.Apply) gopium.Apply {
 			default:
 			}
 			// exec single func
-			pkg, err = fun(ctx, pkg, loc, c)
+			pkg, err = fun(ctx, pkg, tpkg, loc, c)
 			// in case of any error
 			// just propagate it
 			if err != nil {
@@ -75,6 +93,7 @@ func combine(funcs ...gopium.Apply) gopium.Apply {
 func cat(
 	ctx context.Context,
 	pkg *ast.Package,
+	tpkg *types.Package,
 	loc gopium.Locator,
 	c gopium.Categorized,
 ) (*ast.Package, error) {
@@ -96,6 +115,7 @@ func ufmt(w gopium.Walk, fmt gopium.Ast) gopium.Apply {
 	return func(
 		ctx context.Context,
 		pkg *ast.Package,
+		tpkg *types.Package,
 		loc gopium.Locator,
 		c gopium.Categorized,
 	) (*ast.Package, error) {
@@ -125,6 +145,7 @@ func filter(w gopium.Walk) gopium.Apply {
 	return func(
 		ctx context.Context,
 		pkg *ast.Package,
+		tpkg *types.Package,
 		loc gopium.Locator,
 		c gopium.Categorized,
 	) (*ast.Package, error) {
@@ -203,6 +224,7 @@ func note(w gopium.Walk, xp gopium.AstParser, p gopium.Printer) gopium.Apply {
 	return func(
 		ctx context.Context,
 		pkg *ast.Package,
+		tpkg *types.Package,
 		loc gopium.Locator,
 		c gopium.Categorized,
 	) (*ast.Package, error) {
@@ -301,7 +301,7 @@ type tt gopium.Struct
 				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
 			}
 			// exec
-			pkg, err = tcase.a(tcase.ctx, pkg, loc, tcase.h)
+			pkg, err = tcase.a(tcase.ctx, pkg, nil, loc, tcase.h)
 			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
 				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
 			}
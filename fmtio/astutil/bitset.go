@@ -0,0 +1,413 @@
+package astutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/typepkg"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// bitset helps to collapse bool fields
+// to generated flags field accordingly
+// to gopium struct results, to generate
+// flags getter and setter methods
+// and to rewrite all package selectors,
+// assignments and composite literals of bitset structs,
+// as rewrites touch the whole package
+// bitset runs next apply on rewritten package
+// and then restores all rewritten files
+// which were filtered out by next apply
+//
+// accessors methods are placed right after
+// original structs once next apply is done
+// by regenerating ast for each bitset file,
+// note: collapsed fields docs and comments are dropped,
+// collapsed fields addresses can't be taken and
+// collapsed fields can be set in composite literals
+// only by constant values
+func bitset(w gopium.Walk, xp gopium.AstParser, p gopium.Printer, next gopium.Apply) gopium.Apply {
+	return func(
+		ctx context.Context,
+		pkg *ast.Package,
+		tpkg *types.Package,
+		loc gopium.Locator,
+		c gopium.Categorized,
+	) (*ast.Package, error) {
+		// collect all bitset structs
+		fc := &fcollect{}
+		if _, err := w(
+			ctx,
+			pkg,
+			fc,
+			&flatid{loc: loc, sts: collections.Flat(c.Full())},
+		); err != nil {
+			return nil, err
+		}
+		// in case there is nothing to collapse
+		// just run next apply
+		if len(fc.sts) == 0 {
+			return next(ctx, pkg, tpkg, loc, c)
+		}
+		// generic structs can't be collapsed
+		// as accessors can't reuse type params
+		for _, ts := range fc.tss {
+			if ts.TypeParams != nil {
+				return nil, fmt.Errorf("generic struct %q can't be converted to bitset", ts.Name.Name)
+			}
+		}
+		// type check the whole package
+		// before any rewrites happened
+		info, err := typepkg.Check(ctx, tpkg, pkg, loc.Root())
+		if err != nil {
+			return nil, err
+		}
+		// collapse all collected structs
+		// and rewrite their uses
+		bw := bitwrite{
+			info: info,
+			bits: make(map[*types.Var]string),
+			lits: make(map[*types.TypeName]flagged),
+			pls:  make(map[string][]placed),
+		}
+		for i := range fc.sts {
+			if err := bw.collapse(pkg, fc.tss[i], fc.sts[i]); err != nil {
+				return nil, err
+			}
+		}
+		files, err := bw.rewrite(pkg)
+		if err != nil {
+			return nil, err
+		}
+		// run next apply
+		// in case of any error
+		// just return it back
+		rpkg, err := next(ctx, pkg, tpkg, loc, c)
+		if err != nil {
+			return nil, err
+		}
+		// restore all rewritten files
+		// skipped by next apply
+		for name, file := range files {
+			if _, ok := rpkg.Files[name]; !ok {
+				rpkg.Files[name] = file
+			}
+		}
+		// place all accessors methods
+		// into bitset files
+		for name, pls := range bw.pls {
+			if err := place(ctx, xp, p, rpkg, loc, name, pls); err != nil {
+				return nil, err
+			}
+		}
+		return rpkg, ctx.Err()
+	}
+}
+
+// fcollect defines gopium ast walk
+// action bitset structs collector implementation
+type fcollect struct {
+	tss []*ast.TypeSpec `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	sts []gopium.Struct `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_   [16]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
+
+// Visit fcollect implementation
+func (fc *fcollect) Visit(ts *ast.TypeSpec, st gopium.Struct) error {
+	// bitset structs are detectable by
+	// flags field with bitset marker in result
+	// which doesn't exist in ast yet
+	if f, ok := flags(st); ok {
+		for _, field := range ts.Type.(*ast.StructType).Fields.List {
+			for _, name := range field.Names {
+				if name.Name == f.Name {
+					return nil
+				}
+			}
+		}
+		fc.tss = append(fc.tss, ts)
+		fc.sts = append(fc.sts, st)
+	}
+	return nil
+}
+
+// flagged contains bitset struct
+// flags field and collapsed fields bits
+type flagged struct {
+	bits  map[string]int `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	name  string         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	fname string         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [24]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
+
+// bitwrite defines bitset structs rewriter
+// which uses type checked info
+// to find all relevant use sites
+type bitwrite struct {
+	info *types.Info                 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bits map[*types.Var]string       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	lits map[*types.TypeName]flagged `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	pls  map[string][]placed         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
+
+// collapse replaces collapsed ast struct fields
+// with flags field and generates flags accessors
+func (bw bitwrite) collapse(pkg *ast.Package, ts *ast.TypeSpec, st gopium.Struct) error {
+	// collect collapsed fields bits
+	f, _ := flags(st)
+	fl := flagged{name: ts.Name.Name, fname: f.Name, bits: make(map[string]int)}
+	val, _ := collections.Marker(f.Tag, collections.MarkBitset)
+	names := strings.Split(val, ",")
+	for bit, name := range names {
+		fl.bits[name] = bit
+	}
+	// check that accessors don't conflict
+	// with existing struct fields and methods
+	tn, ok := bw.info.Defs[ts.Name].(*types.TypeName)
+	if ok {
+		for _, name := range names {
+			_, setter := collections.Bitset(name)
+			if obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(tn.Type()), false, tn.Pkg(), name); obj != nil {
+				if _, ok := obj.(*types.Func); ok {
+					return fmt.Errorf("bitset getter %q of struct %q conflicts with existing method", name, fl.name)
+				}
+			}
+			if obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(tn.Type()), false, tn.Pkg(), setter); obj != nil {
+				return fmt.Errorf("bitset setter %q of struct %q conflicts with existing field or method", setter, fl.name)
+			}
+		}
+	}
+	// go through all ast fields
+	// and remove collapsed fields names
+	tts := ts.Type.(*ast.StructType)
+	kept := make([]*ast.Field, 0, len(tts.Fields.List))
+	for _, field := range tts.Fields.List {
+		if len(field.Names) == 0 {
+			kept = append(kept, field)
+			continue
+		}
+		knames := make([]*ast.Ident, 0, len(field.Names))
+		for _, name := range field.Names {
+			if _, ok := fl.bits[name.Name]; !ok {
+				knames = append(knames, name)
+			}
+		}
+		if len(knames) > 0 {
+			kfield := *field
+			kfield.Names = knames
+			kept = append(kept, &kfield)
+		}
+	}
+	// replace collapsed fields with flags field
+	tts.Fields.List = append(kept, &ast.Field{
+		Names: []*ast.Ident{ast.NewIdent(f.Name)},
+		Type:  ast.NewIdent(f.Type),
+		Tag:   &ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("`%s`", f.Tag)},
+	})
+	// collect accessors decls for original struct file
+	var buf bytes.Buffer
+	for bit, name := range names {
+		_, setter := collections.Bitset(name)
+		fmt.Fprintf(&buf, "\n// %s returns %s flag from %s bitset\n", name, name, f.Name)
+		fmt.Fprintf(&buf, "func (s %s) %s() bool {\n", fl.name, name)
+		fmt.Fprintf(&buf, "return s.%s&(1<<%d) != 0\n", f.Name, bit)
+		fmt.Fprint(&buf, "}\n")
+		fmt.Fprintf(&buf, "\n// %s updates %s flag in %s bitset\n", setter, name, f.Name)
+		fmt.Fprintf(&buf, "func (s *%s) %s(v bool) {\n", fl.name, setter)
+		fmt.Fprint(&buf, "if v {\n")
+		fmt.Fprintf(&buf, "s.%s |= 1 << %d\n", f.Name, bit)
+		fmt.Fprint(&buf, "return\n")
+		fmt.Fprint(&buf, "}\n")
+		fmt.Fprintf(&buf, "s.%s &^= 1 << %d\n", f.Name, bit)
+		fmt.Fprint(&buf, "}\n")
+	}
+	name := locate(pkg, ts)
+	bw.pls[name] = append(bw.pls[name], placed{name: fl.name, src: buf.Bytes()})
+	// collect collapsed fields types vars
+	if ok {
+		if tst, ok := tn.Type().Underlying().(*types.Struct); ok {
+			for i := 0; i < tst.NumFields(); i++ {
+				if f := tst.Field(i); !f.Embedded() {
+					if _, ok := fl.bits[f.Name()]; ok {
+						_, setter := collections.Bitset(f.Name())
+						bw.bits[f] = setter
+					}
+				}
+			}
+		}
+		bw.lits[tn] = fl
+	}
+	return nil
+}
+
+// rewrite rewrites all package selectors, assignments
+// and composite literals of bitset structs
+// and returns all rewritten files back
+func (bw bitwrite) rewrite(pkg *ast.Package) (map[string]*ast.File, error) {
+	files := make(map[string]*ast.File)
+	for name, file := range pkg.Files {
+		var err error
+		var rewritten bool
+		astutil.Apply(file, func(c *astutil.Cursor) bool {
+			switch n := c.Node().(type) {
+			// rewrite `x.f = v` assignment to `x.setF(v)`
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					se, ok := lhs.(*ast.SelectorExpr)
+					if !ok {
+						continue
+					}
+					setter, ok := bw.setter(se)
+					if !ok {
+						continue
+					}
+					if len(n.Lhs) != 1 || n.Tok != token.ASSIGN {
+						err = fmt.Errorf("assignment to bitset field %q can't be rewritten", se.Sel.Name)
+						return false
+					}
+					c.Replace(&ast.ExprStmt{X: &ast.CallExpr{
+						Fun:  &ast.SelectorExpr{X: se.X, Sel: ast.NewIdent(setter)},
+						Args: n.Rhs,
+					}})
+					rewritten = true
+				}
+			// collapsed fields addresses can't be taken
+			case *ast.UnaryExpr:
+				if se, ok := n.X.(*ast.SelectorExpr); ok && n.Op == token.AND {
+					if _, ok := bw.setter(se); ok {
+						err = fmt.Errorf("address of bitset field %q can't be taken", se.Sel.Name)
+						return false
+					}
+				}
+			// collapsed fields can't be range vars
+			case *ast.RangeStmt:
+				for _, expr := range []ast.Expr{n.Key, n.Value} {
+					if se, ok := expr.(*ast.SelectorExpr); ok {
+						if _, ok := bw.setter(se); ok {
+							err = fmt.Errorf("range over bitset field %q can't be rewritten", se.Sel.Name)
+							return false
+						}
+					}
+				}
+			case *ast.CompositeLit:
+				ok, lerr := bw.literal(n)
+				if lerr != nil {
+					err = lerr
+					return false
+				}
+				rewritten = rewritten || ok
+			}
+			return err == nil
+		}, func(c *astutil.Cursor) bool {
+			// rewrite `x.f` selector to `x.f()`
+			// after all inner nodes are rewritten
+			if se, ok := c.Node().(*ast.SelectorExpr); ok && err == nil {
+				if _, ok := bw.setter(se); ok {
+					c.Replace(&ast.CallExpr{Fun: se})
+					rewritten = true
+				}
+			}
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if rewritten {
+			files[name] = file
+		}
+	}
+	return files, nil
+}
+
+// setter returns setter name for selector
+// in case selection is collapsed field
+func (bw bitwrite) setter(se *ast.SelectorExpr) (string, bool) {
+	sel, ok := bw.info.Selections[se]
+	if !ok || sel.Kind() != types.FieldVal {
+		return "", false
+	}
+	v, ok := sel.Obj().(*types.Var)
+	if !ok {
+		return "", false
+	}
+	setter, ok := bw.bits[v]
+	return setter, ok
+}
+
+// literal rewrites `T{f: true}` composite literal
+// to `T{flags: 1}` for bitset structs
+func (bw bitwrite) literal(lit *ast.CompositeLit) (bool, error) {
+	tv, ok := bw.info.Types[lit]
+	if !ok {
+		return false, nil
+	}
+	named, ok := tv.Type.(*types.Named)
+	if !ok {
+		return false, nil
+	}
+	fl, ok := bw.lits[named.Obj()]
+	if !ok || len(lit.Elts) == 0 {
+		return false, nil
+	}
+	// unkeyed literals can't be rewritten
+	if _, ok := lit.Elts[0].(*ast.KeyValueExpr); !ok {
+		return false, fmt.Errorf("unkeyed composite literal of struct %q can't be rewritten to bitset", fl.name)
+	}
+	// collapse literal elements
+	// with constant values to flags value
+	var flags uint64
+	var collapsed bool
+	elts := make([]ast.Expr, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				if bit, ok := fl.bits[key.Name]; ok {
+					val := bw.info.Types[kv.Value].Value
+					if val == nil || val.Kind() != constant.Bool {
+						return false, fmt.Errorf("bitset field %q of struct %q can't be set by non constant value in composite literal", key.Name, fl.name)
+					}
+					if constant.BoolVal(val) {
+						flags |= 1 << bit
+					}
+					collapsed = true
+					continue
+				}
+			}
+		}
+		elts = append(elts, elt)
+	}
+	if !collapsed {
+		return false, nil
+	}
+	// set flags value only
+	// if any flag is set
+	if flags != 0 {
+		elts = append(elts, &ast.KeyValueExpr{
+			Key:   ast.NewIdent(fl.fname),
+			Value: &ast.BasicLit{Kind: token.INT, Value: strconv.FormatUint(flags, 10)},
+		})
+	}
+	lit.Elts = elts
+	return true, nil
+}
+
+// flags finds bitset flags field
+// inside provided gopium struct
+func flags(st gopium.Struct) (gopium.Field, bool) {
+	for _, f := range st.Fields {
+		if val, ok := collections.Marker(f.Tag, collections.MarkBitset); ok && val != "" && strings.HasPrefix(f.Type, "uint") {
+			return f, true
+		}
+	}
+	return gopium.Field{}, false
+}
@@ -0,0 +1,224 @@
+package astutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/parser"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+func TestBitset(t *testing.T) {
+	// prepare
+	h := collections.NewHierarchic(tests.Gopium)
+	h.Push(
+		"tests_data_bitset_file-1.go:6",
+		filepath.Join(tests.Gopium, "tests", "data", "bitset", "file-1.go"),
+		gopium.Struct{
+			Name: "Config",
+			Fields: []gopium.Field{
+				{
+					Name: "Name",
+					Type: "string",
+					Size: 16,
+				},
+				{
+					Name: "flags",
+					Type: "uint8",
+					Size: 1,
+					Tag:  `gopium:"bitset:ready,debug,verbose"`,
+				},
+				{
+					Name: "cached",
+					Type: "bool",
+					Size: 1,
+				},
+			},
+		},
+	)
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := fmtio.Gofmt{}
+	xp := &typepkg.ParserXToolPackagesAst{
+		ModeAst: parser.ParseComments | parser.AllErrors,
+	}
+	sp := Package{}
+	table := map[string]struct {
+		a   gopium.Apply
+		ctx context.Context
+		h   collections.Hierarchic
+		r   map[string][]byte
+		err error
+	}{
+		"bitset pkg without bitset structs should apply nothing": {
+			a:   bitset(walk, xp, p, combine()),
+			ctx: context.Background(),
+			r:   map[string][]byte{},
+		},
+		"bitset pkg should apply expected bitset structs": {
+			a:   UFFN,
+			ctx: context.Background(),
+			h:   h,
+			r: map[string][]byte{
+				"tests_data_bitset_file-1.go": []byte(`
+//go:build tests_data
+
+package bitset
+
+// Config doc
+type Config struct {
+	Name   string
+	flags  uint8 ` + "`gopium:\"bitset:ready,debug,verbose\"`" + `
+	cached bool
+} // config comment
+
+// ready returns ready flag from flags bitset
+func (s Config) ready() bool {
+	return s.flags&(1<<0) != 0
+}
+
+// setReady updates ready flag in flags bitset
+func (s *Config) setReady(v bool) {
+	if v {
+		s.flags |= 1 << 0
+		return
+	}
+	s.flags &^= 1 << 0
+}
+
+// debug returns debug flag from flags bitset
+func (s Config) debug() bool {
+	return s.flags&(1<<1) != 0
+}
+
+// setDebug updates debug flag in flags bitset
+func (s *Config) setDebug(v bool) {
+	if v {
+		s.flags |= 1 << 1
+		return
+	}
+	s.flags &^= 1 << 1
+}
+
+// verbose returns verbose flag from flags bitset
+func (s Config) verbose() bool {
+	return s.flags&(1<<2) != 0
+}
+
+// setVerbose updates verbose flag in flags bitset
+func (s *Config) setVerbose(v bool) {
+	if v {
+		s.flags |= 1 << 2
+		return
+	}
+	s.flags &^= 1 << 2
+}
+
+// Other doc
+type Other struct {
+	Config
+	c Config
+}
+`),
+				"tests_data_bitset_file-2.go": []byte(`
+//go:build tests_data
+
+package bitset
+
+func enable(c *Config) {
+	c.setReady(true)
+	if c.debug() {
+		c.setVerbose(!c.ready())
+	}
+}
+
+func create() Config {
+	return Config{Name: "test", cached: true, flags: 1}
+}
+
+func verbose(o Other) bool {
+	return o.verbose() || o.c.debug()
+}
+
+func other() *Other {
+	return &Other{c: Config{flags: 4}}
+}
+`),
+			},
+		},
+		"bitset pkg should return error on canceled context": {
+			a:   bitset(walk, xp, p, combine()),
+			ctx: cctx,
+			h:   h,
+			r:   map[string][]byte{},
+			err: context.Canceled,
+		},
+		"bitset pkg should return error on next apply error": {
+			a:   bitset(walk, xp, p, mocks.Apply{Err: errors.New("test-1")}.Apply),
+			ctx: context.Background(),
+			h:   h,
+			r:   map[string][]byte{},
+			err: errors.New("test-1"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			w := &mocks.Writer{}
+			pkg, loc, err := data.NewParser("bitset").ParseAst(context.Background())
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			// exec
+			pkg, err = tcase.a(tcase.ctx, pkg, nil, loc, tcase.h)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// prepare
+			if pkg != nil {
+				err = sp.Persist(context.Background(), p, data.Writer{Writer: w}, loc, pkg)
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+				}
+			}
+			// check
+			for name, rwc := range w.RWCs {
+				// check all struct
+				// against bytes map
+				if st, ok := tcase.r[name]; ok {
+					// read rwc to buffer
+					var buf bytes.Buffer
+					_, err := buf.ReadFrom(rwc)
+					if !reflect.DeepEqual(err, nil) {
+						t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+					}
+					// format actual and expected identically
+					actual := strings.Trim(buf.String(), "\n")
+					expected := strings.Trim(string(st), "\n")
+					if !reflect.DeepEqual(actual, expected) {
+						t.Errorf("name %v actual %v doesn't equal to expected %v", name, actual, expected)
+					}
+					delete(tcase.r, name)
+				} else {
+					t.Errorf("actual %v doesn't equal to expected %v", name, "")
+				}
+			}
+			// check that map has been drained
+			if !reflect.DeepEqual(tcase.r, map[string][]byte{}) {
+				t.Errorf("actual %v doesn't equal to expected %v", tcase.r, map[string][]byte{})
+			}
+		})
+	}
+}
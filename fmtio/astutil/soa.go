@@ -0,0 +1,337 @@
+package astutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+// SOA implements apply and combines:
+// - soa helper
+var SOA = soa(
+	walk,
+	&typepkg.ParserXToolPackagesAst{
+		ModeAst: parser.ParseComments | parser.AllErrors,
+	},
+	fmtio.Gofmt{},
+)
+
+// soa helps to generate struct of arrays
+// companion types accordingly to gopium struct results,
+// for each struct `T` companion `TSoA` with one slice
+// per struct result field and `Append`, `Get`, `Set`, `Len`
+// methods is generated, all companions are placed
+// to new package files named after original files
+// so original package files are not affected,
+// note: blank fields and fields that don't exist
+// in original struct are skipped, structs from
+// test files or generated companion files are skipped
+func soa(w gopium.Walk, xp gopium.AstParser, p gopium.Printer) gopium.Apply {
+	return func(
+		ctx context.Context,
+		pkg *ast.Package,
+		tpkg *types.Package,
+		loc gopium.Locator,
+		c gopium.Categorized,
+	) (*ast.Package, error) {
+		// collect all structs
+		// with their ast type specs
+		ac := &acollect{}
+		if _, err := w(
+			ctx,
+			pkg,
+			ac,
+			&flatid{loc: loc, sts: collections.Flat(c.Full())},
+		); err != nil {
+			return nil, err
+		}
+		// collect all package level names
+		// with their declaration files
+		decls := soadecls(pkg)
+		// group collected structs by their files
+		// and keep original structs order
+		files := make(map[string][]int)
+		for i, ts := range ac.tss {
+			// structs from test files and previously
+			// generated companion files are skipped
+			name := loc.Loc(ts.Pos())
+			if strings.HasSuffix(name, "_test.go") || generated(pkg.Files[name]) {
+				continue
+			}
+			// generic structs can't be converted
+			// as companion can't reuse type params
+			if ts.TypeParams != nil {
+				return nil, fmt.Errorf("generic struct %q can't be converted to struct of arrays", ts.Name.Name)
+			}
+			// companion name can't clash with any
+			// package level name except previously
+			// generated companion that is overwritten
+			cname := ts.Name.Name + "SoA"
+			if dname, ok := decls[cname]; ok && !generated(pkg.Files[dname]) {
+				return nil, fmt.Errorf("struct %q companion %q is already declared in package", ts.Name.Name, cname)
+			}
+			files[name] = append(files[name], i)
+		}
+		rpkg := &ast.Package{Name: pkg.Name, Files: make(map[string]*ast.File)}
+		for name, idxs := range files {
+			sort.Slice(idxs, func(i, j int) bool {
+				return ac.tss[idxs[i]].Pos() < ac.tss[idxs[j]].Pos()
+			})
+			// generate companions file source
+			// and parse it back to ast
+			src, err := soagen(ctx, p, pkg.Files[name], tpkg, loc, ac, idxs)
+			if err != nil {
+				return nil, err
+			}
+			// skip files without any companion
+			if src == nil {
+				continue
+			}
+			npkg, nloc, err := xp.ParseAst(ctx, src...)
+			if err != nil {
+				return nil, err
+			}
+			rpkg.Files[name] = npkg.Files["file"]
+			loc.Fset(name, nloc.Root())
+		}
+		return rpkg, ctx.Err()
+	}
+}
+
+// soagen generates companions file source
+// for provided structs of original file
+func soagen(
+	ctx context.Context,
+	p gopium.Printer,
+	file *ast.File,
+	tpkg *types.Package,
+	loc gopium.Locator,
+	ac *acollect,
+	idxs []int,
+) ([]byte, error) {
+	var decls bytes.Buffer
+	used := make(map[string]bool)
+	for _, i := range idxs {
+		ts, st := ac.tss[i], ac.sts[i]
+		// collect original fields types
+		// by their names
+		ftypes := make(map[string]ast.Expr)
+		for _, field := range ts.Type.(*ast.StructType).Fields.List {
+			for _, name := range field.Names {
+				ftypes[name.Name] = field.Type
+			}
+			if len(field.Names) == 0 {
+				ftypes[embname(field.Type)] = field.Type
+			}
+		}
+		// collect companion columns
+		// in struct result fields order
+		var names, types []string
+		for _, f := range st.Fields {
+			expr, ok := ftypes[f.Name]
+			if f.Name == "_" || !ok {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := p.Print(ctx, &buf, loc.Root(), expr); err != nil {
+				return nil, err
+			}
+			// collect used imports names
+			ast.Inspect(expr, func(node ast.Node) bool {
+				if sel, ok := node.(*ast.SelectorExpr); ok {
+					if id, ok := sel.X.(*ast.Ident); ok {
+						used[id.Name] = true
+					}
+				}
+				return true
+			})
+			names = append(names, f.Name)
+			types = append(types, buf.String())
+		}
+		// skip structs without columns
+		if len(names) == 0 {
+			continue
+		}
+		soadecl(&decls, ts.Name.Name, names, types)
+	}
+	// skip files without companions
+	if decls.Len() == 0 {
+		return nil, nil
+	}
+	// write file header with build constraints
+	// package clause and used imports
+	var src bytes.Buffer
+	for _, cg := range file.Comments {
+		if cg.Pos() >= file.Package {
+			break
+		}
+		for _, com := range cg.List {
+			if strings.HasPrefix(com.Text, "//go:build") {
+				fmt.Fprintf(&src, "%s\n\n", com.Text)
+			}
+		}
+	}
+	fmt.Fprintf(&src, "// Code generated by %s. DO NOT EDIT.\n\n", gopium.STAMP)
+	fmt.Fprintf(&src, "package %s\n\n", file.Name.Name)
+	var imports []string
+	for _, spec := range file.Imports {
+		ipath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		// use types package imports
+		// to find actual package name
+		name := path.Base(ipath)
+		if tpkg != nil {
+			for _, imp := range tpkg.Imports() {
+				if imp.Path() == ipath {
+					name = imp.Name()
+				}
+			}
+		}
+		switch {
+		case spec.Name != nil && used[spec.Name.Name]:
+			imports = append(imports, fmt.Sprintf("%s %s", spec.Name.Name, spec.Path.Value))
+		case spec.Name == nil && used[name]:
+			imports = append(imports, spec.Path.Value)
+		}
+	}
+	if len(imports) > 0 {
+		fmt.Fprintf(&src, "import (\n%s\n)\n", strings.Join(imports, "\n"))
+	}
+	_, _ = src.Write(decls.Bytes())
+	return src.Bytes(), nil
+}
+
+// soadecl writes struct of arrays companion
+// type and its methods decls for provided struct
+func soadecl(buf *bytes.Buffer, name string, names []string, types []string) {
+	cname := name + "SoA"
+	// companion type decl
+	fmt.Fprintf(buf, "\n// %s defines struct of arrays companion for %s\n", cname, name)
+	fmt.Fprintf(buf, "type %s struct {\n", cname)
+	for i := range names {
+		fmt.Fprintf(buf, "%s []%s\n", names[i], types[i])
+	}
+	fmt.Fprint(buf, "}\n")
+	// append method decl
+	fmt.Fprintf(buf, "\n// Append appends provided %s to %s\n", name, cname)
+	fmt.Fprintf(buf, "func (s *%s) Append(v %s) {\n", cname, name)
+	for _, n := range names {
+		fmt.Fprintf(buf, "s.%s = append(s.%s, v.%s)\n", n, n, n)
+	}
+	fmt.Fprint(buf, "}\n")
+	// get method decl
+	fmt.Fprintf(buf, "\n// Get returns %s at provided index\n", name)
+	fmt.Fprintf(buf, "func (s *%s) Get(i int) %s {\n", cname, name)
+	fmt.Fprintf(buf, "return %s{\n", name)
+	for _, n := range names {
+		fmt.Fprintf(buf, "%s: s.%s[i],\n", n, n)
+	}
+	fmt.Fprint(buf, "}\n}\n")
+	// set method decl
+	fmt.Fprintf(buf, "\n// Set updates %s at provided index\n", name)
+	fmt.Fprintf(buf, "func (s *%s) Set(i int, v %s) {\n", cname, name)
+	for _, n := range names {
+		fmt.Fprintf(buf, "s.%s[i] = v.%s\n", n, n)
+	}
+	fmt.Fprint(buf, "}\n")
+	// len method decl
+	fmt.Fprintf(buf, "\n// Len returns number of %s in %s\n", name, cname)
+	fmt.Fprintf(buf, "func (s *%s) Len() int {\n", cname)
+	fmt.Fprintf(buf, "return len(s.%s)\n", names[0])
+	fmt.Fprint(buf, "}\n")
+}
+
+// soadecls collects all package level
+// names with their declaration files
+func soadecls(pkg *ast.Package) map[string]string {
+	decls := make(map[string]string)
+	for name, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					decls[d.Name.Name] = name
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch sp := spec.(type) {
+					case *ast.TypeSpec:
+						decls[sp.Name.Name] = name
+					case *ast.ValueSpec:
+						for _, id := range sp.Names {
+							decls[id.Name] = name
+						}
+					}
+				}
+			}
+		}
+	}
+	return decls
+}
+
+// generated checks if provided file
+// is companions file generated by gopium
+func generated(file *ast.File) bool {
+	if file == nil {
+		return false
+	}
+	header := fmt.Sprintf("// Code generated by %s. DO NOT EDIT.", gopium.STAMP)
+	for _, cg := range file.Comments {
+		if cg.Pos() >= file.Package {
+			break
+		}
+		for _, com := range cg.List {
+			if com.Text == header {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// embname returns embedded field name
+// from embedded field type expression
+func embname(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embname(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return embname(t.X)
+	case *ast.IndexListExpr:
+		return embname(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// acollect defines gopium ast walk
+// action structs collector implementation
+type acollect struct {
+	tss []*ast.TypeSpec `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	sts []gopium.Struct `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_   [16]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
+
+// Visit acollect implementation
+func (ac *acollect) Visit(ts *ast.TypeSpec, st gopium.Struct) error {
+	ac.tss = append(ac.tss, ts)
+	ac.sts = append(ac.sts, st)
+	return nil
+}
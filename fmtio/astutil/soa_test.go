@@ -0,0 +1,271 @@
+package astutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestSoa(t *testing.T) {
+	// prepare
+	h := collections.NewHierarchic(tests.Gopium)
+	h.Push(
+		"tests_data_soa_file-1.go:11",
+		filepath.Join(tests.Gopium, "tests", "data", "soa", "file-1.go"),
+		gopium.Struct{
+			Name: "Point",
+			Fields: []gopium.Field{
+				{
+					Name: "Builder",
+					Type: "*strings.Builder",
+					Size: 8,
+				},
+				{
+					Name: "At",
+					Type: "time.Time",
+					Size: 24,
+				},
+				{
+					Name: "Y",
+					Type: "int",
+					Size: 8,
+				},
+				{
+					Name: "X",
+					Type: "int",
+					Size: 8,
+				},
+				{
+					Name: "_",
+					Type: "[8]byte",
+					Size: 8,
+				},
+				{
+					Name: "cold",
+					Type: "*PointCold",
+					Size: 8,
+				},
+			},
+		},
+	)
+	h.Push(
+		"tests_data_soa_file-1.go:20",
+		filepath.Join(tests.Gopium, "tests", "data", "soa", "file-1.go"),
+		gopium.Struct{
+			Name: "Empty",
+		},
+	)
+	h.Push(
+		"tests_data_soa_file-1.soa.go:8",
+		filepath.Join(tests.Gopium, "tests", "data", "soa", "file-1.soa.go"),
+		gopium.Struct{
+			Name: "PointSoA",
+			Fields: []gopium.Field{
+				{
+					Name: "X",
+					Type: "[]int",
+					Size: 24,
+				},
+			},
+		},
+	)
+	h.Push(
+		"tests_data_soa_file-3_test.go:6",
+		filepath.Join(tests.Gopium, "tests", "data", "soa", "file-3_test.go"),
+		gopium.Struct{
+			Name: "Test",
+			Fields: []gopium.Field{
+				{
+					Name: "A",
+					Type: "int",
+					Size: 8,
+				},
+			},
+		},
+	)
+	hc := collections.NewHierarchic(tests.Gopium)
+	hc.Push(
+		"tests_data_soa_file-4.go:6",
+		filepath.Join(tests.Gopium, "tests", "data", "soa", "file-4.go"),
+		gopium.Struct{
+			Name: "Clash",
+			Fields: []gopium.Field{
+				{
+					Name: "A",
+					Type: "int",
+					Size: 8,
+				},
+			},
+		},
+	)
+	hg := collections.NewHierarchic(tests.Gopium)
+	hg.Push(
+		"tests_data_soa_file-2.go:6",
+		filepath.Join(tests.Gopium, "tests", "data", "soa", "file-2.go"),
+		gopium.Struct{
+			Name: "Generic",
+			Fields: []gopium.Field{
+				{
+					Name: "V",
+					Type: "T",
+				},
+			},
+		},
+	)
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := fmtio.Gofmt{}
+	sp := Package{}
+	table := map[string]struct {
+		a   gopium.Apply
+		ctx context.Context
+		h   collections.Hierarchic
+		r   map[string][]byte
+		err error
+	}{
+		"soa pkg without structs should apply nothing": {
+			a:   SOA,
+			ctx: context.Background(),
+			r:   map[string][]byte{},
+		},
+		"soa pkg should apply expected companions skipping test and generated files": {
+			a:   SOA,
+			ctx: context.Background(),
+			h:   h,
+			r: map[string][]byte{
+				"tests_data_soa_file-1.go": []byte(`
+//go:build tests_data
+
+// Code generated by 🌺 gopium @1pkg. DO NOT EDIT.
+
+package soa
+
+import (
+	"strings"
+	t "time"
+)
+
+// PointSoA defines struct of arrays companion for Point
+type PointSoA struct {
+	Builder []*strings.Builder
+	At      []t.Time
+	Y       []int
+	X       []int
+}
+
+// Append appends provided Point to PointSoA
+func (s *PointSoA) Append(v Point) {
+	s.Builder = append(s.Builder, v.Builder)
+	s.At = append(s.At, v.At)
+	s.Y = append(s.Y, v.Y)
+	s.X = append(s.X, v.X)
+}
+
+// Get returns Point at provided index
+func (s *PointSoA) Get(i int) Point {
+	return Point{
+		Builder: s.Builder[i],
+		At:      s.At[i],
+		Y:       s.Y[i],
+		X:       s.X[i],
+	}
+}
+
+// Set updates Point at provided index
+func (s *PointSoA) Set(i int, v Point) {
+	s.Builder[i] = v.Builder
+	s.At[i] = v.At
+	s.Y[i] = v.Y
+	s.X[i] = v.X
+}
+
+// Len returns number of Point in PointSoA
+func (s *PointSoA) Len() int {
+	return len(s.Builder)
+}
+`),
+			},
+		},
+		"soa pkg should return error on generic structs": {
+			a:   SOA,
+			ctx: context.Background(),
+			h:   hg,
+			r:   map[string][]byte{},
+			err: errors.New(`generic struct "Generic" can't be converted to struct of arrays`),
+		},
+		"soa pkg should return error on already declared companions": {
+			a:   SOA,
+			ctx: context.Background(),
+			h:   hc,
+			r:   map[string][]byte{},
+			err: errors.New(`struct "Clash" companion "ClashSoA" is already declared in package`),
+		},
+		"soa pkg should return error on canceled context": {
+			a:   SOA,
+			ctx: cctx,
+			h:   h,
+			r:   map[string][]byte{},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			w := &mocks.Writer{}
+			pkg, loc, err := data.NewParser("soa").ParseAst(context.Background())
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			// exec
+			pkg, err = tcase.a(tcase.ctx, pkg, nil, loc, tcase.h)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// prepare
+			if pkg != nil {
+				err = sp.Persist(context.Background(), p, data.Writer{Writer: w}, loc, pkg)
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+				}
+			}
+			// check
+			for name, rwc := range w.RWCs {
+				// check all struct
+				// against bytes map
+				if st, ok := tcase.r[name]; ok {
+					// read rwc to buffer
+					var buf bytes.Buffer
+					_, err := buf.ReadFrom(rwc)
+					if !reflect.DeepEqual(err, nil) {
+						t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+					}
+					// format actual and expected identically
+					actual := strings.Trim(buf.String(), "\n")
+					expected := strings.Trim(string(st), "\n")
+					if !reflect.DeepEqual(actual, expected) {
+						t.Errorf("name %v actual %v doesn't equal to expected %v", name, actual, expected)
+					}
+					delete(tcase.r, name)
+				} else {
+					t.Errorf("actual %v doesn't equal to expected %v", name, "")
+				}
+			}
+			// check that map has been drained
+			if !reflect.DeepEqual(tcase.r, map[string][]byte{}) {
+				t.Errorf("actual %v doesn't equal to expected %v", tcase.r, map[string][]byte{})
+			}
+		})
+	}
+}
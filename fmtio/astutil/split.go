@@ -0,0 +1,551 @@
+package astutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+// split helps to move cold fields
+// to generated companion structs
+// accordingly to gopium struct results
+// and to rewrite all package selectors
+// and composite literals of split structs,
+// as rewrites touch the whole package
+// split runs next apply on rewritten package
+// and then restores all rewritten files
+// which were filtered out by next apply
+//
+// companion structs and their accessors are placed
+// right after original structs once next apply is done
+// by regenerating ast for each split file,
+// companion is accessed through nil safe accessors
+// which allocate companion on first access,
+// note: moved fields docs and comments are dropped
+func split(w gopium.Walk, xp gopium.AstParser, p gopium.Printer, next gopium.Apply) gopium.Apply {
+	return func(
+		ctx context.Context,
+		pkg *ast.Package,
+		tpkg *types.Package,
+		loc gopium.Locator,
+		c gopium.Categorized,
+	) (*ast.Package, error) {
+		// collect all split structs
+		sc := &scollect{}
+		if _, err := w(
+			ctx,
+			pkg,
+			sc,
+			&flatid{loc: loc, sts: collections.Flat(c.Full())},
+		); err != nil {
+			return nil, err
+		}
+		// in case there is nothing to split
+		// just run next apply
+		if len(sc.sts) == 0 {
+			return next(ctx, pkg, tpkg, loc, c)
+		}
+		// generic structs can't be split
+		// as companion can't reuse type params
+		for _, ts := range sc.tss {
+			if ts.TypeParams != nil {
+				return nil, fmt.Errorf("generic struct %q can't be split", ts.Name.Name)
+			}
+		}
+		// type check the whole package
+		// before any rewrites happened
+		info, err := typepkg.Check(ctx, tpkg, pkg, loc.Root())
+		if err != nil {
+			return nil, err
+		}
+		// split all collected structs
+		// and rewrite their uses
+		rw := rewrite{
+			info:  info,
+			moved: make(map[*types.Var]splitted),
+			lits:  make(map[*types.TypeName]splitted),
+			sps:   make(map[string][]splitted),
+			vals:  make(map[string]bool),
+		}
+		for i := range sc.sts {
+			if err := rw.split(pkg, sc.tss[i], sc.sts[i]); err != nil {
+				return nil, err
+			}
+		}
+		files, err := rw.rewrite(pkg)
+		if err != nil {
+			return nil, err
+		}
+		// run next apply
+		// in case of any error
+		// just return it back
+		rpkg, err := next(ctx, pkg, tpkg, loc, c)
+		if err != nil {
+			return nil, err
+		}
+		// restore all rewritten files
+		// skipped by next apply
+		for name, file := range files {
+			if _, ok := rpkg.Files[name]; !ok {
+				rpkg.Files[name] = file
+			}
+		}
+		// place all companion structs
+		// into split files
+		for name, sps := range rw.sps {
+			pls := make([]placed, 0, len(sps))
+			for _, sp := range sps {
+				// print companion decl
+				// and its accessors to buffer
+				var buf bytes.Buffer
+				if err := p.Print(ctx, &buf, loc.Root(), sp.decl); err != nil {
+					return nil, err
+				}
+				sp.accessors(&buf, rw.vals[sp.name])
+				pls = append(pls, placed{name: sp.name, src: buf.Bytes()})
+			}
+			if err := place(ctx, xp, p, rpkg, loc, name, pls); err != nil {
+				return nil, err
+			}
+		}
+		return rpkg, ctx.Err()
+	}
+}
+
+// placed contains printed decls source
+// that should be placed right after
+// struct decl with provided name
+type placed struct {
+	src  []byte   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	name string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [24]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
+
+// place helps to place generated decls
+// right after original structs decls inside file,
+// it prints file, inserts generated decls
+// right after original structs lines
+// and parses file back to ast
+func place(
+	ctx context.Context,
+	xp gopium.AstParser,
+	p gopium.Printer,
+	pkg *ast.Package,
+	loc gopium.Locator,
+	name string,
+	pls []placed,
+) error {
+	file, ok := pkg.Files[name]
+	if !ok {
+		return nil
+	}
+	// print ast to buffer
+	var buf bytes.Buffer
+	fset, _ := loc.Fset(name, nil)
+	if err := p.Print(ctx, &buf, fset, file); err != nil {
+		return err
+	}
+	src := buf.Bytes()
+	// go through all generated decls
+	// and insert them one by one
+	for _, pl := range pls {
+		// parse ast back to file
+		// to find original struct line
+		pkg, nloc, err := xp.ParseAst(ctx, src...)
+		if err != nil {
+			return err
+		}
+		off := len(src)
+		ast.Inspect(pkg.Files["file"], func(node ast.Node) bool {
+			if ts, ok := node.(*ast.TypeSpec); ok && ts.Name.Name == pl.name {
+				tf := nloc.Root().File(ts.End())
+				if line := tf.Line(ts.End()); line < tf.LineCount() {
+					off = tf.Offset(tf.LineStart(line + 1))
+				}
+			}
+			return off == len(src)
+		})
+		// insert generated decl
+		// after original struct line
+		decl := append([]byte("\n"), bytes.Trim(pl.src, "\n")...)
+		decl = append(decl, '\n')
+		src = append(src[:off:off], append(decl, src[off:]...)...)
+	}
+	// parse ast back to file
+	// and push child fset to locator
+	npkg, nloc, err := xp.ParseAst(ctx, src...)
+	if err != nil {
+		return err
+	}
+	pkg.Files[name] = npkg.Files["file"]
+	loc.Fset(name, nloc.Root())
+	return nil
+}
+
+// scollect defines gopium ast walk
+// action split structs collector implementation
+type scollect struct {
+	tss []*ast.TypeSpec `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	sts []gopium.Struct `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_   [16]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
+
+// Visit scollect implementation
+func (sc *scollect) Visit(ts *ast.TypeSpec, st gopium.Struct) error {
+	// split structs are detectable by
+	// companion pointer field in result
+	// which doesn't exist in ast yet
+	ctype, cname := collections.Cold(ts.Name.Name)
+	for _, f := range st.Fields {
+		if f.Name == cname && f.Type == "*"+ctype {
+			for _, field := range ts.Type.(*ast.StructType).Fields.List {
+				for _, name := range field.Names {
+					if name.Name == cname {
+						return nil
+					}
+				}
+			}
+			sc.tss = append(sc.tss, ts)
+			sc.sts = append(sc.sts, st)
+			return nil
+		}
+	}
+	return nil
+}
+
+// splitted contains split struct
+// companion type and field names
+type splitted struct {
+	names map[string]struct{} `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	decl  *ast.GenDecl        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	name  string              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ctype string              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	cname string              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 56 bytes; - 🌺 gopium @1pkg
+
+// refval returns split struct companion
+// allocating and non allocating accessors names
+func (sp splitted) refval() (string, string) {
+	return sp.cname + "Ref", sp.cname + "Val"
+}
+
+// accessors writes split struct companion
+// allocating accessor decl and optionally
+// non allocating accessor decl used
+// for non addressable struct values
+func (sp splitted) accessors(buf *bytes.Buffer, val bool) {
+	ref, vname := sp.refval()
+	fmt.Fprintf(buf, "\n// %s returns %s companion of %s\n", ref, sp.ctype, sp.name)
+	fmt.Fprint(buf, "// and allocates it on first access\n")
+	fmt.Fprintf(buf, "func (x *%s) %s() *%s {\n", sp.name, ref, sp.ctype)
+	fmt.Fprintf(buf, "\tif x.%s == nil {\n", sp.cname)
+	fmt.Fprintf(buf, "\t\tx.%s = &%s{}\n", sp.cname, sp.ctype)
+	fmt.Fprint(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn x.%s\n", sp.cname)
+	fmt.Fprint(buf, "}\n")
+	if !val {
+		return
+	}
+	fmt.Fprintf(buf, "\n// %s returns %s companion of %s\n", vname, sp.ctype, sp.name)
+	fmt.Fprint(buf, "// or zero companion if it's not allocated\n")
+	fmt.Fprintf(buf, "func (x %s) %s() *%s {\n", sp.name, vname, sp.ctype)
+	fmt.Fprintf(buf, "\tif x.%s == nil {\n", sp.cname)
+	fmt.Fprintf(buf, "\t\treturn &%s{}\n", sp.ctype)
+	fmt.Fprint(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn x.%s\n", sp.cname)
+	fmt.Fprint(buf, "}\n")
+}
+
+// rewrite defines split structs rewriter
+// which uses type checked info
+// to find all relevant use sites
+type rewrite struct {
+	info  *types.Info                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	moved map[*types.Var]splitted      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	lits  map[*types.TypeName]splitted `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	sps   map[string][]splitted        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	vals  map[string]bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [24]byte                     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 40 bytes; - 🌺 gopium @1pkg
+
+// split moves cold ast struct fields
+// that are missing in result struct
+// to generated companion struct decl
+func (rw rewrite) split(pkg *ast.Package, ts *ast.TypeSpec, st gopium.Struct) error {
+	// collect result fields names
+	ctype, cname := collections.Cold(ts.Name.Name)
+	names := make(map[string]struct{}, len(st.Fields))
+	for _, f := range st.Fields {
+		names[f.Name] = struct{}{}
+	}
+	// go through all ast fields
+	// and collect moved and kept fields
+	sp := splitted{name: ts.Name.Name, ctype: ctype, cname: cname, names: make(map[string]struct{})}
+	tts := ts.Type.(*ast.StructType)
+	kept := make([]*ast.Field, 0, len(tts.Fields.List))
+	var moved []*ast.Field
+	for _, field := range tts.Fields.List {
+		// skip all non cold fields
+		var tag string
+		if field.Tag != nil {
+			tag, _ = strconv.Unquote(field.Tag.Value)
+		}
+		if _, ok := collections.Marker(tag, collections.MarkCold); !ok {
+			kept = append(kept, field)
+			continue
+		}
+		// in case of embedded field
+		// use its type name instead
+		fnames := field.Names
+		if len(fnames) == 0 {
+			fnames = []*ast.Ident{ast.NewIdent(embedded(field.Type))}
+		}
+		// split field names to moved and kept
+		var knames, mnames []*ast.Ident
+		for _, name := range fnames {
+			if _, ok := names[name.Name]; ok || name.Name == "_" {
+				knames = append(knames, name)
+				continue
+			}
+			sp.names[name.Name] = struct{}{}
+			mnames = append(mnames, ast.NewIdent(name.Name))
+		}
+		// build moved field copy
+		if len(mnames) > 0 {
+			mfield := &ast.Field{Names: mnames, Type: field.Type}
+			if len(field.Names) == 0 {
+				mfield.Names = nil
+			}
+			if field.Tag != nil {
+				mfield.Tag = &ast.BasicLit{Kind: token.STRING, Value: field.Tag.Value}
+			}
+			moved = append(moved, mfield)
+		}
+		// keep the rest of field names
+		if len(knames) > 0 {
+			kfield := *field
+			if len(field.Names) > 0 {
+				kfield.Names = knames
+			}
+			kept = append(kept, &kfield)
+		}
+	}
+	// replace moved fields with companion pointer
+	tts.Fields.List = append(kept, &ast.Field{
+		Names: []*ast.Ident{ast.NewIdent(cname)},
+		Type:  &ast.StarExpr{X: ast.NewIdent(ctype)},
+	})
+	// collect companion decl for original struct file
+	sp.decl = &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(ctype),
+				Type: &ast.StructType{Fields: &ast.FieldList{List: moved}},
+			},
+		},
+	}
+	name := locate(pkg, ts)
+	rw.sps[name] = append(rw.sps[name], sp)
+	// collect moved fields types vars
+	if tn, ok := rw.info.Defs[ts.Name].(*types.TypeName); ok {
+		// companion accessors can't clash
+		// with struct fields or methods
+		ref, val := sp.refval()
+		for _, acc := range []string{ref, val} {
+			if obj, _, _ := types.LookupFieldOrMethod(tn.Type(), true, tn.Pkg(), acc); obj != nil {
+				return fmt.Errorf("struct %q can't be split as its companion accessor %q is already declared", sp.name, acc)
+			}
+		}
+		if tst, ok := tn.Type().Underlying().(*types.Struct); ok {
+			for i := 0; i < tst.NumFields(); i++ {
+				if f := tst.Field(i); f.Name() != "_" {
+					if _, ok := sp.names[f.Name()]; ok {
+						rw.moved[f] = sp
+					}
+				}
+			}
+		}
+		rw.lits[tn] = sp
+	}
+	return nil
+}
+
+// rewrite rewrites all package selectors
+// and composite literals of split structs
+// and returns all rewritten files back
+func (rw rewrite) rewrite(pkg *ast.Package) (map[string]*ast.File, error) {
+	files := make(map[string]*ast.File)
+	for name, file := range pkg.Files {
+		var err error
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.SelectorExpr:
+				if rw.selector(n) {
+					files[name] = file
+				}
+			case *ast.CompositeLit:
+				ok, lerr := rw.literal(n)
+				if lerr != nil {
+					err = lerr
+					return false
+				}
+				if ok {
+					files[name] = file
+				}
+			}
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// selector rewrites `x.f` selector to `x.coldRef().f`
+// in case selection goes through moved field,
+// promoted selections are rewritten to full
+// selection path e.g. `x.f` to `x.E.coldRef().f`,
+// non addressable struct values e.g. map values
+// use non allocating `x.coldVal().f` accessor instead
+func (rw rewrite) selector(se *ast.SelectorExpr) bool {
+	sel, ok := rw.info.Selections[se]
+	if !ok || sel.Kind() == types.MethodExpr {
+		return false
+	}
+	// collect all selection field hops
+	// for method value skip method index
+	idx := sel.Index()
+	if sel.Kind() == types.MethodVal {
+		idx = idx[:len(idx)-1]
+	}
+	// go through all selection hops
+	// and build full selection path
+	x, moved := se.X, false
+	t := sel.Recv()
+	addr := rw.info.Types[se.X].Addressable()
+	for n, i := range idx {
+		// dereference pointer types
+		// which are always addressable
+		if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			t = ptr.Elem()
+			addr = true
+		}
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			return false
+		}
+		f := st.Field(i)
+		// in case hop is moved field
+		// insert companion accessor call
+		if sp, ok := rw.moved[f]; ok {
+			acc, val := sp.refval()
+			if !addr {
+				acc = val
+				rw.vals[sp.name] = true
+			}
+			x = &ast.CallExpr{Fun: &ast.SelectorExpr{X: x, Sel: ast.NewIdent(acc)}}
+			moved, addr = true, true
+		}
+		// the last field hop of field selection
+		// is selector itself, the rest of hops
+		// are embedded fields selectors
+		if sel.Kind() == types.MethodVal || n < len(idx)-1 {
+			x = &ast.SelectorExpr{X: x, Sel: ast.NewIdent(f.Name())}
+		}
+		t = f.Type()
+	}
+	if moved {
+		se.X = x
+	}
+	return moved
+}
+
+// literal rewrites `T{f: v}` composite literal
+// to `T{cold: &TCold{f: v}}` for split structs,
+// literals without cold fields are kept intact
+// as companion is allocated on first access
+func (rw rewrite) literal(lit *ast.CompositeLit) (bool, error) {
+	tv, ok := rw.info.Types[lit]
+	if !ok {
+		return false, nil
+	}
+	named, ok := tv.Type.(*types.Named)
+	if !ok {
+		return false, nil
+	}
+	sp, ok := rw.lits[named.Obj()]
+	if !ok {
+		return false, nil
+	}
+	// unkeyed literals can't be rewritten
+	if len(lit.Elts) > 0 {
+		if _, ok := lit.Elts[0].(*ast.KeyValueExpr); !ok {
+			return false, fmt.Errorf("unkeyed composite literal of struct %q can't be rewritten to split", sp.name)
+		}
+	}
+	// split literal elements
+	elts := make([]ast.Expr, 0, len(lit.Elts)+1)
+	var celts []ast.Expr
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				if _, ok := sp.names[key.Name]; ok {
+					celts = append(celts, elt)
+					continue
+				}
+			}
+		}
+		elts = append(elts, elt)
+	}
+	// skip literals without cold fields
+	if len(celts) == 0 {
+		return false, nil
+	}
+	lit.Elts = append(elts, &ast.KeyValueExpr{
+		Key: ast.NewIdent(sp.cname),
+		Value: &ast.UnaryExpr{
+			Op: token.AND,
+			X:  &ast.CompositeLit{Type: ast.NewIdent(sp.ctype), Elts: celts},
+		},
+	})
+	return true, nil
+}
+
+// locate returns ast package file name
+// which contains provided type spec
+func locate(pkg *ast.Package, ts *ast.TypeSpec) string {
+	for name, file := range pkg.Files {
+		if file.Pos() <= ts.Pos() && ts.End() <= file.End() {
+			return name
+		}
+	}
+	return ""
+}
+
+// embedded returns embedded field name
+// from embedded field type expression
+func embedded(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return embedded(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return embedded(t.X)
+	case *ast.IndexListExpr:
+		return embedded(t.X)
+	}
+	return ""
+}
@@ -0,0 +1,296 @@
+package astutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/parser"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+func TestSplit(t *testing.T) {
+	// prepare
+	h := collections.NewHierarchic(tests.Gopium)
+	h.Push(
+		"tests_data_split_file-1.go:6",
+		filepath.Join(tests.Gopium, "tests", "data", "split", "file-1.go"),
+		gopium.Struct{
+			Name: "Session",
+			Fields: []gopium.Field{
+				{
+					Name: "ID",
+					Type: "int64",
+					Size: 8,
+				},
+				{
+					Name: "Token",
+					Type: "string",
+					Size: 16,
+				},
+				{
+					Name: "cold",
+					Type: "*SessionCold",
+					Size: 8,
+				},
+			},
+		},
+	)
+	ph := collections.NewHierarchic(tests.Gopium)
+	ph.Push(
+		"tests_data_split_file-4.go:6",
+		filepath.Join(tests.Gopium, "tests", "data", "split", "file-4.go"),
+		gopium.Struct{
+			Name: "Pair",
+			Fields: []gopium.Field{
+				{
+					Name: "Key",
+					Type: "int64",
+					Size: 8,
+				},
+				{
+					Name: "cold",
+					Type: "*PairCold",
+					Size: 8,
+				},
+			},
+		},
+	)
+	lh := collections.NewHierarchic(tests.Gopium)
+	lh.Push(
+		"tests_data_split_file-5.go:6",
+		filepath.Join(tests.Gopium, "tests", "data", "split", "file-5.go"),
+		gopium.Struct{
+			Name: "Lazy",
+			Fields: []gopium.Field{
+				{
+					Name: "Key",
+					Type: "int64",
+					Size: 8,
+				},
+				{
+					Name: "cold",
+					Type: "*LazyCold",
+					Size: 8,
+				},
+			},
+		},
+	)
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := fmtio.Gofmt{}
+	xp := &typepkg.ParserXToolPackagesAst{
+		ModeAst: parser.ParseComments | parser.AllErrors,
+	}
+	sp := Package{}
+	table := map[string]struct {
+		a   gopium.Apply
+		ctx context.Context
+		h   collections.Hierarchic
+		r   map[string][]byte
+		err error
+	}{
+		"split pkg without split structs should apply nothing": {
+			a:   split(walk, xp, p, combine()),
+			ctx: context.Background(),
+			r:   map[string][]byte{},
+		},
+		"split pkg should apply expected split structs": {
+			a:   UFFN,
+			ctx: context.Background(),
+			h:   h,
+			r: map[string][]byte{
+				"tests_data_split_file-1.go": []byte(`
+//go:build tests_data
+
+package split
+
+// Session doc
+type Session struct {
+	ID    int64
+	Token string
+	cold  *SessionCold
+} // session comment
+
+type SessionCold struct {
+	Name string ` + "`gopium:\"cold\"`" + `
+	Meta []byte ` + "`json:\"meta\" gopium:\"cold\"`" + `
+}
+
+// coldRef returns SessionCold companion of Session
+// and allocates it on first access
+func (x *Session) coldRef() *SessionCold {
+	if x.cold == nil {
+		x.cold = &SessionCold{}
+	}
+	return x.cold
+}
+
+// coldVal returns SessionCold companion of Session
+// or zero companion if it's not allocated
+func (x Session) coldVal() *SessionCold {
+	if x.cold == nil {
+		return &SessionCold{}
+	}
+	return x.cold
+}
+
+// Other doc
+type Other struct {
+	S Session
+}
+
+// Wrapper doc
+type Wrapper struct {
+	*Session
+}
+`),
+				"tests_data_split_file-2.go": []byte(`
+//go:build tests_data
+
+package split
+
+func name(s *Session) string {
+	return s.coldRef().Name
+}
+
+func create() Session {
+	return Session{ID: 1, Token: "token", cold: &SessionCold{Name: "test"}}
+}
+
+func meta(o Other) int {
+	return len(o.S.coldRef().Meta) + len(o.S.Token)
+}
+
+func promoted(w Wrapper) string {
+	return w.Session.coldRef().Name + w.Token
+}
+
+func first(ss map[int64]Session) string {
+	return ss[0].coldVal().Name
+}
+`),
+			},
+		},
+		"split pkg should return error on unkeyed composite literal": {
+			a:   UFFN,
+			ctx: context.Background(),
+			h:   ph,
+			r:   map[string][]byte{},
+			err: errors.New(`unkeyed composite literal of struct "Pair" can't be rewritten to split`),
+		},
+		"split pkg should apply expected split structs with zero values": {
+			a:   UFFN,
+			ctx: context.Background(),
+			h:   lh,
+			r: map[string][]byte{
+				"tests_data_split_file-5.go": []byte(`
+//go:build tests_data
+
+package split
+
+// Lazy doc
+type Lazy struct {
+	Key  int64
+	cold *LazyCold
+}
+
+type LazyCold struct {
+	Value string ` + "`gopium:\"cold\"`" + `
+}
+
+// coldRef returns LazyCold companion of Lazy
+// and allocates it on first access
+func (x *Lazy) coldRef() *LazyCold {
+	if x.cold == nil {
+		x.cold = &LazyCold{}
+	}
+	return x.cold
+}
+
+func lazy() *Lazy {
+	return new(Lazy)
+}
+
+func value(l *Lazy) string {
+	var zero Lazy
+	return l.coldRef().Value + zero.coldRef().Value
+}
+`),
+			},
+		},
+		"split pkg should return error on canceled context": {
+			a:   split(walk, xp, p, combine()),
+			ctx: cctx,
+			h:   h,
+			r:   map[string][]byte{},
+			err: context.Canceled,
+		},
+		"split pkg should return error on next apply error": {
+			a:   split(walk, xp, p, mocks.Apply{Err: errors.New("test-1")}.Apply),
+			ctx: context.Background(),
+			h:   h,
+			r:   map[string][]byte{},
+			err: errors.New("test-1"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			w := &mocks.Writer{}
+			pkg, loc, err := data.NewParser("split").ParseAst(context.Background())
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			// exec
+			pkg, err = tcase.a(tcase.ctx, pkg, nil, loc, tcase.h)
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// prepare
+			if pkg != nil {
+				err = sp.Persist(context.Background(), p, data.Writer{Writer: w}, loc, pkg)
+				if !reflect.DeepEqual(err, nil) {
+					t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+				}
+			}
+			// check
+			for name, rwc := range w.RWCs {
+				// check all struct
+				// against bytes map
+				if st, ok := tcase.r[name]; ok {
+					// read rwc to buffer
+					var buf bytes.Buffer
+					_, err := buf.ReadFrom(rwc)
+					if !reflect.DeepEqual(err, nil) {
+						t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+					}
+					// format actual and expected identically
+					actual := strings.Trim(buf.String(), "\n")
+					expected := strings.Trim(string(st), "\n")
+					if !reflect.DeepEqual(actual, expected) {
+						t.Errorf("name %v actual %v doesn't equal to expected %v", name, actual, expected)
+					}
+					delete(tcase.r, name)
+				} else {
+					t.Errorf("actual %v doesn't equal to expected %v", name, "")
+				}
+			}
+			// check that map has been drained
+			if !reflect.DeepEqual(tcase.r, map[string][]byte{}) {
+				t.Errorf("actual %v doesn't equal to expected %v", tcase.r, map[string][]byte{})
+			}
+		})
+	}
+}
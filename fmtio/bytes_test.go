@@ -37,6 +37,21 @@ func TestBytes(t *testing.T) {
 		"Fields": null
 	}
 ]
+`),
+		},
+		"json should return expected result for struct with importance in collection": {
+			fmt: Jsonb,
+			f:   collections.Flat{"test": gopium.Struct{Name: "Test", Importance: 3}},
+			r: []byte(`
+[
+	{
+		"Name": "Test",
+		"Doc": null,
+		"Comment": null,
+		"Fields": null,
+		"Importance": 3
+	}
+]
 `),
 		},
 		"json should return expected result for non empty collection": {
@@ -156,6 +171,16 @@ func TestBytes(t *testing.T) {
 <Struct>
 	<Name></Name>
 </Struct>
+`),
+		},
+		"xml should return expected result for struct with importance in collection": {
+			fmt: Xmlb,
+			f:   collections.Flat{"test": gopium.Struct{Name: "Test", Importance: 3}},
+			r: []byte(`
+<Struct>
+	<Name>Test</Name>
+	<Importance>3</Importance>
+</Struct>
 `),
 		},
 		"xml should return valid expected result for non empty collection": {
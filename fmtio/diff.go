@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -131,69 +132,116 @@ const (
 // which compares two categorized collections
 // to formatted markdown table byte slice
 func SizeAlignMdt(o gopium.Categorized, r gopium.Categorized) ([]byte, error) {
+	return sizealignmdt(o, r, nil, false)
+}
+
+// SizeAlignImportanceMdt defines diff implementation
+// which compares two categorized collections
+// to formatted markdown table byte slice
+// with importance column and rows ranked
+// by structs importances and then by structs ids
+func SizeAlignImportanceMdt(o gopium.Categorized, r gopium.Categorized) ([]byte, error) {
+	return sizealignmdt(o, r, func(sti, stj gopium.Struct) bool {
+		return sti.Importance > stj.Importance
+	}, true)
+}
+
+// sizealignmdt helps to compare two categorized collections
+// to formatted markdown table byte slice,
+// rows are ordered by provided less func
+// and then by structs ids if less func is provided,
+// optionally with structs importances column
+func sizealignmdt(
+	o gopium.Categorized,
+	r gopium.Categorized,
+	less func(gopium.Struct, gopium.Struct) bool,
+	importance bool,
+) ([]byte, error) {
 	// prepare buffer and collections
 	var buf bytes.Buffer
 	var tsizeo, tsizer int64
 	var tptro, tptrr int64
+	var timp int64
 	fo, fr := o.Full(), r.Full()
+	// collect ids of structs
+	// contained by both collections
+	// and order them if needed
+	ids := make([]string, 0, len(fo))
+	for id := range fo {
+		if _, ok := fr[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+	if less != nil {
+		sort.SliceStable(ids, func(i, j int) bool {
+			sti, stj := fo[ids[i]], fo[ids[j]]
+			switch {
+			case less(sti, stj):
+				return true
+			case less(stj, sti):
+				return false
+			default:
+				return ids[i] < ids[j]
+			}
+		})
+	}
+	// prepare optional importance
+	// header columns
+	var head, align string
+	if importance {
+		head, align = " Importance |", " :---: |"
+	}
 	// write header
 	// no error should be
 	// checked as it uses
 	// buffered writer
-	_, _ = buf.WriteString("| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference |\n")
-	_, _ = buf.WriteString("| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |\n")
-	for id, sto := range fo {
-		// if both collections contains
-		// struct, compare them
-		if stf, ok := fr[id]; ok {
-			// get aligned size and align
-			sizeo, _, ptro := collections.SizeAlignPtr(sto)
-			sizer, _, ptrr := collections.SizeAlignPtr(stf)
-			// write diff info
-			// no error should be
-			// checked as it uses
-			// buffered writer
-			_, _ = buf.WriteString(
-				fmt.Sprintf(
-					"| %s | %d bytes | %d bytes | %+d bytes | %+.2f%% | %d bytes | %d bytes | %+d bytes | %+.2f%% |\n",
-					sto.Name,
-					sizeo,
-					sizer,
-					sizer-sizeo,
-					float64(sizer-sizeo)/float64(sizeo)*100.0,
-					ptro,
-					ptrr,
-					ptrr-ptro,
-					float64(ptrr-ptro)/float64(ptro)*100.0,
-				),
-			)
-			// increment total sizes
-			tsizeo += sizeo
-			tsizer += sizer
-			tptro += ptro
-			tptrr += ptrr
-		}
-	}
-	// zero divide guard
-	if tsizeo > 0 {
-		// write diff info
+	_, _ = buf.WriteString("| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference |" + head + "\n")
+	_, _ = buf.WriteString("| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |" + align + "\n")
+	// write defines row writer helper
+	write := func(name string, sizeo, sizer, ptro, ptrr, imp int64) {
 		// no error should be
 		// checked as it uses
 		// buffered writer
 		_, _ = buf.WriteString(
 			fmt.Sprintf(
-				"| %s | %d bytes | %d bytes | %+d bytes | %+.2f%% | %d bytes | %d bytes | %+d bytes | %+.2f%% |\n",
-				"Total",
-				tsizeo,
-				tsizer,
-				tsizer-tsizeo,
-				float64(tsizer-tsizeo)/float64(tsizeo)*100.0,
-				tptro,
-				tptrr,
-				tptrr-tptro,
-				float64(tptrr-tptro)/float64(tptro)*100.0,
+				"| %s | %d bytes | %d bytes | %+d bytes | %+.2f%% | %d bytes | %d bytes | %+d bytes | %+.2f%% |",
+				name,
+				sizeo,
+				sizer,
+				sizer-sizeo,
+				float64(sizer-sizeo)/float64(sizeo)*100.0,
+				ptro,
+				ptrr,
+				ptrr-ptro,
+				float64(ptrr-ptro)/float64(ptro)*100.0,
 			),
 		)
+		if importance {
+			_, _ = buf.WriteString(fmt.Sprintf(" %d |", imp))
+		}
+		_, _ = buf.WriteString("\n")
+	}
+	for _, id := range ids {
+		// compare structs from
+		// both collections
+		sto, stf := fo[id], fr[id]
+		// get aligned size and align
+		sizeo, _, ptro := collections.SizeAlignPtr(sto)
+		sizer, _, ptrr := collections.SizeAlignPtr(stf)
+		// write diff info
+		write(sto.Name, sizeo, sizer, ptro, ptrr, sto.Importance)
+		// increment total sizes
+		// and importances
+		tsizeo += sizeo
+		tsizer += sizer
+		tptro += ptro
+		tptrr += ptrr
+		timp += sto.Importance
+	}
+	// zero divide guard
+	if tsizeo > 0 {
+		// write diff info
+		write("Total", tsizeo, tsizer, tptro, tptrr, timp)
 	}
 	return buf.Bytes(), nil
 }
@@ -108,6 +108,33 @@ func TestDiff(t *testing.T) {
 			},
 		},
 	})
+	ih := collections.NewHierarchic("")
+	ihr := collections.NewHierarchic("")
+	for _, st := range []gopium.Struct{
+		{
+			Name:       "test1",
+			Importance: 1,
+			Fields: []gopium.Field{
+				{Name: "test1", Size: 1, Align: 1},
+				{Name: "test2", Size: 8, Align: 8, Ptr: 8},
+				{Name: "test3", Size: 1, Align: 1},
+			},
+		},
+		{
+			Name:       "test2",
+			Importance: 5,
+			Fields: []gopium.Field{
+				{Name: "test1", Size: 8, Align: 8, Ptr: 8},
+			},
+		},
+	} {
+		ih.Push(st.Name, "test", st)
+		r := collections.CopyStruct(st)
+		if len(r.Fields) > 1 {
+			r.Fields = []gopium.Field{r.Fields[1], r.Fields[0], r.Fields[2]}
+		}
+		ihr.Push(st.Name, "test", r)
+	}
 	table := map[string]struct {
 		fmt gopium.Diff
 		o   gopium.Categorized
@@ -144,6 +171,27 @@ func TestDiff(t *testing.T) {
 | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
 | test | 24 bytes | 32 bytes | +8 bytes | +33.33% | 17 bytes | 30 bytes | +13 bytes | +76.47% |
 | Total | 24 bytes | 32 bytes | +8 bytes | +33.33% | 17 bytes | 30 bytes | +13 bytes | +76.47% |
+`),
+		},
+		"size align importance md table should return expected result for empty collections": {
+			fmt: SizeAlignImportanceMdt,
+			o:   collections.NewHierarchic(""),
+			r:   collections.NewHierarchic(""),
+			b: []byte(`
+| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference | Importance |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+`),
+		},
+		"size align importance md table should return expected result for collections with importances": {
+			fmt: SizeAlignImportanceMdt,
+			o:   ih,
+			r:   ihr,
+			b: []byte(`
+| Struct Name | Original Size with Pad | Current Size with Pad | Absolute Size Difference | Relative Size Difference | Original Ptr Size with Pad | Current Ptr Size with Pad | Absolute Ptr Size Difference | Relative Ptr Size Difference | Importance |
+| :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: | :---: |
+| test2 | 8 bytes | 8 bytes | +0 bytes | +0.00% | 8 bytes | 8 bytes | +0 bytes | +0.00% | 5 |
+| test1 | 24 bytes | 16 bytes | -8 bytes | -33.33% | 16 bytes | 8 bytes | -8 bytes | -50.00% | 1 |
+| Total | 32 bytes | 24 bytes | -8 bytes | -25.00% | 24 bytes | 16 bytes | -8 bytes | -33.33% | 6 |
 `),
 		},
 		"fields html table should return expected result for empty collections": {
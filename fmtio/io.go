@@ -10,6 +10,7 @@ import (
 const (
 	GOPIUM = "gopium"
 	GO     = "go"
+	SOA    = "soa.go"
 	JSON   = "json"
 	XML    = "xml"
 	CSV    = "csv"
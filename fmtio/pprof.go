@@ -0,0 +1,364 @@
+package fmtio
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// list of pprof profile proto fields numbers
+const (
+	// profile fields
+	pprofSampleType  = 1
+	pprofSample      = 2
+	pprofLocation    = 4
+	pprofFunction    = 5
+	pprofStringTable = 6
+	pprofDefaultType = 14
+	// sample fields
+	pprofSampleLocations = 1
+	pprofSampleValues    = 2
+	// location fields
+	pprofLocationID   = 1
+	pprofLocationLine = 4
+	// line fields
+	pprofLineFunction = 1
+	pprofLineLine     = 2
+	// function fields
+	pprofFunctionID       = 1
+	pprofFunctionName     = 2
+	pprofFunctionFilename = 4
+	// value type fields
+	pprofValueTypeType = 1
+)
+
+// Pprof defines gopium profile implementation
+// that weights source code lines by pprof profile samples,
+// sample value of provided sample type or profile default
+// sample type (or the last one) is attributed only to
+// the first non runtime line of sample stack,
+// source files are matched to profile files by
+// the longest common path suffix as profiles usually contain
+// paths from the build environment, at least directory
+// and file names should match and ambiguous matches are skipped
+type Pprof struct {
+	Lines  map[string]map[int]int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Sample string                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [40]byte                 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// NewPprof decodes gzipped or raw pprof profile
+// proto from provided reader to pprof instance
+// using provided sample type values if any
+func NewPprof(r io.Reader, stype string) (Pprof, error) {
+	br := bufio.NewReader(r)
+	// unzip profile only if it has gzip magic header
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return Pprof{}, err
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = br
+	}
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return Pprof{}, err
+	}
+	return decodePprof(buf, stype)
+}
+
+// Weight pprof implementation
+func (p Pprof) Weight(file string, line int) int64 {
+	return p.Lines[p.match(file)][line]
+}
+
+// SampleType pprof implementation
+func (p Pprof) SampleType() string {
+	return p.Sample
+}
+
+// match finds profile file with the longest
+// common path suffix with provided file
+func (p Pprof) match(file string) string {
+	parts := strings.Split(filepath.ToSlash(file), "/")
+	var best string
+	var bcommon int
+	var ambiguous bool
+	for pfile := range p.Lines {
+		common := pprofSuffix(parts, strings.Split(pfile, "/"))
+		switch {
+		case common > bcommon:
+			best, bcommon, ambiguous = pfile, common, false
+		case common == bcommon:
+			ambiguous = true
+		}
+	}
+	// at least directory and file names
+	// should match unambiguously
+	if bcommon < 2 || ambiguous {
+		return ""
+	}
+	return best
+}
+
+// pprofSuffix counts number of common
+// trailing path elements of two paths
+func pprofSuffix(p1 []string, p2 []string) int {
+	var common int
+	for i, j := len(p1)-1, len(p2)-1; i >= 0 && j >= 0 && p1[i] == p2[j]; i, j = i-1, j-1 {
+		common++
+	}
+	return common
+}
+
+// pprofLine defines single location line
+// with its function and line number
+type pprofLine struct {
+	function uint64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	line     int64  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// decodePprof decodes raw pprof profile proto
+// and collects samples values of provided sample type
+// by the first non runtime lines of samples stacks
+func decodePprof(buf []byte, stype string) (Pprof, error) {
+	var types, samples, locations, functions [][]byte
+	var strs []string
+	var deftype int64
+	// collect all profile top level messages
+	err := pprofFields(buf, func(num uint64, val uint64, msg []byte) error {
+		switch num {
+		case pprofSampleType:
+			types = append(types, msg)
+		case pprofSample:
+			samples = append(samples, msg)
+		case pprofLocation:
+			locations = append(locations, msg)
+		case pprofFunction:
+			functions = append(functions, msg)
+		case pprofStringTable:
+			strs = append(strs, string(msg))
+		case pprofDefaultType:
+			deftype = int64(val)
+		}
+		return nil
+	})
+	if err != nil {
+		return Pprof{}, err
+	}
+	// find index of provided sample type value
+	// or default sample type value
+	// or use the last sample type value
+	index, found := len(types)-1, stype == ""
+	tnames := make([]string, len(types))
+	for i, tp := range types {
+		err := pprofFields(tp, func(num uint64, val uint64, msg []byte) error {
+			if num != pprofValueTypeType {
+				return nil
+			}
+			if val < uint64(len(strs)) {
+				tnames[i] = strs[val]
+			}
+			switch {
+			case stype != "" && val < uint64(len(strs)) && strs[val] == stype:
+				index, found = i, true
+			case stype == "" && deftype != 0 && int64(val) == deftype:
+				index = i
+			}
+			return nil
+		})
+		if err != nil {
+			return Pprof{}, err
+		}
+	}
+	if !found {
+		return Pprof{}, fmt.Errorf("pprof profile has no sample type %q", stype)
+	}
+	// collect functions names and filenames
+	names, files := make(map[uint64]string, len(functions)), make(map[uint64]string, len(functions))
+	for _, fn := range functions {
+		var id, name, file uint64
+		err := pprofFields(fn, func(num uint64, val uint64, msg []byte) error {
+			switch num {
+			case pprofFunctionID:
+				id = val
+			case pprofFunctionName:
+				name = val
+			case pprofFunctionFilename:
+				file = val
+			}
+			return nil
+		})
+		if err != nil {
+			return Pprof{}, err
+		}
+		if name < uint64(len(strs)) {
+			names[id] = strs[name]
+		}
+		if file < uint64(len(strs)) {
+			files[id] = strs[file]
+		}
+	}
+	// collect locations lines
+	// from the leaf inlined line
+	lines := make(map[uint64][]pprofLine, len(locations))
+	for _, loc := range locations {
+		var id uint64
+		var llines []pprofLine
+		err := pprofFields(loc, func(num uint64, val uint64, msg []byte) error {
+			switch num {
+			case pprofLocationID:
+				id = val
+			case pprofLocationLine:
+				var l pprofLine
+				err := pprofFields(msg, func(num uint64, val uint64, msg []byte) error {
+					switch num {
+					case pprofLineFunction:
+						l.function = val
+					case pprofLineLine:
+						l.line = int64(val)
+					}
+					return nil
+				})
+				llines = append(llines, l)
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return Pprof{}, err
+		}
+		lines[id] = llines
+	}
+	// attribute samples values
+	// to the first non runtime lines
+	p := Pprof{Lines: make(map[string]map[int]int64)}
+	if index >= 0 {
+		p.Sample = tnames[index]
+	}
+	for _, sample := range samples {
+		var locs []uint64
+		var vals []int64
+		err := pprofFields(sample, func(num uint64, val uint64, msg []byte) error {
+			switch num {
+			case pprofSampleLocations:
+				if msg == nil {
+					locs = append(locs, val)
+					return nil
+				}
+				return pprofPacked(msg, func(val uint64) {
+					locs = append(locs, val)
+				})
+			case pprofSampleValues:
+				if msg == nil {
+					vals = append(vals, int64(val))
+					return nil
+				}
+				return pprofPacked(msg, func(val uint64) {
+					vals = append(vals, int64(val))
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return Pprof{}, err
+		}
+		if index < 0 || index >= len(vals) {
+			continue
+		}
+	stack:
+		for _, loc := range locs {
+			for _, l := range lines[loc] {
+				if strings.HasPrefix(names[l.function], "runtime.") {
+					continue
+				}
+				file, ok := files[l.function]
+				if !ok {
+					break stack
+				}
+				file = filepath.ToSlash(file)
+				if p.Lines[file] == nil {
+					p.Lines[file] = make(map[int]int64)
+				}
+				p.Lines[file][int(l.line)] += vals[index]
+				break stack
+			}
+		}
+	}
+	return p, nil
+}
+
+// pprofFields decodes proto message fields one by one
+// and passes them to provided callback,
+// varint fields are passed as values
+// and length delimited fields as messages
+func pprofFields(buf []byte, cb func(num uint64, val uint64, msg []byte) error) error {
+	for len(buf) > 0 {
+		key, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return errors.New("pprof profile has invalid field key")
+		}
+		buf = buf[n:]
+		num := key >> 3
+		switch key & 7 {
+		// varint
+		case 0:
+			val, n := binary.Uvarint(buf)
+			if n <= 0 {
+				return errors.New("pprof profile has invalid varint field")
+			}
+			buf = buf[n:]
+			if err := cb(num, val, nil); err != nil {
+				return err
+			}
+		// fixed 64 bits
+		case 1:
+			if len(buf) < 8 {
+				return errors.New("pprof profile has invalid fixed field")
+			}
+			buf = buf[8:]
+		// length delimited
+		case 2:
+			l, n := binary.Uvarint(buf)
+			if n <= 0 || uint64(len(buf)-n) < l {
+				return errors.New("pprof profile has invalid length delimited field")
+			}
+			msg := buf[n : n+int(l)]
+			buf = buf[n+int(l):]
+			if err := cb(num, 0, msg); err != nil {
+				return err
+			}
+		// fixed 32 bits
+		case 5:
+			if len(buf) < 4 {
+				return errors.New("pprof profile has invalid fixed field")
+			}
+			buf = buf[4:]
+		default:
+			return errors.New("pprof profile has unsupported field type")
+		}
+	}
+	return nil
+}
+
+// pprofPacked decodes packed varint values
+// and passes them to provided callback
+func pprofPacked(buf []byte, cb func(val uint64)) error {
+	for len(buf) > 0 {
+		val, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return errors.New("pprof profile has invalid packed field")
+		}
+		buf = buf[n:]
+		cb(val)
+	}
+	return nil
+}
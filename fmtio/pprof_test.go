@@ -0,0 +1,236 @@
+package fmtio
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestPprof(t *testing.T) {
+	// prepare
+	varint := func(num uint64, val uint64) []byte {
+		buf := binary.AppendUvarint(nil, num<<3)
+		return binary.AppendUvarint(buf, val)
+	}
+	message := func(num uint64, msgs ...[]byte) []byte {
+		msg := bytes.Join(msgs, nil)
+		buf := binary.AppendUvarint(nil, num<<3|2)
+		buf = binary.AppendUvarint(buf, uint64(len(msg)))
+		return append(buf, msg...)
+	}
+	packed := func(num uint64, vals ...uint64) []byte {
+		var buf []byte
+		for _, val := range vals {
+			buf = binary.AppendUvarint(buf, val)
+		}
+		return message(num, buf)
+	}
+	profile := func(deftype uint64) []byte {
+		buf := bytes.Join([][]byte{
+			// sample types
+			message(1, varint(1, 1), varint(2, 2)),
+			message(1, varint(1, 3), varint(2, 4)),
+			// samples
+			message(2, packed(1, 1, 3), packed(2, 1, 100)),
+			message(2, packed(1, 2), packed(2, 2, 200)),
+			message(2, varint(1, 3), varint(1, 1), varint(2, 3), varint(2, 300)),
+			message(2, packed(1, 1), packed(2, 4, 400)),
+			message(2, packed(1, 4), packed(2, 5, 500)),
+			message(2, packed(1, 5, 1), packed(2, 6, 600)),
+			// locations
+			message(4, varint(1, 1), message(4, varint(1, 1), varint(2, 10))),
+			message(4, varint(1, 2), message(4, varint(1, 1), varint(2, 20)), message(4, varint(1, 2), varint(2, 30))),
+			message(4, varint(1, 3), message(4, varint(1, 2), varint(2, 40))),
+			message(4, varint(1, 5), message(4, varint(1, 3), varint(2, 50))),
+			// functions
+			message(5, varint(1, 1), varint(2, 5), varint(4, 6)),
+			message(5, varint(1, 2), varint(2, 5), varint(4, 7)),
+			message(5, varint(1, 3), varint(2, 8), varint(4, 9)),
+			// string table
+			message(6),
+			message(6, []byte("samples")),
+			message(6, []byte("count")),
+			message(6, []byte("cpu")),
+			message(6, []byte("nanoseconds")),
+			message(6, []byte("main")),
+			message(6, []byte("/build/src/svc/pkg/file.go")),
+			message(6, []byte("/build/src/svc/other/file.go")),
+			message(6, []byte("runtime.mallocgc")),
+			message(6, []byte("/usr/local/go/src/runtime/malloc.go")),
+		}, nil)
+		if deftype > 0 {
+			buf = append(buf, varint(14, deftype)...)
+		}
+		return buf
+	}
+	gzipped := func(buf []byte) io.Reader {
+		var gz bytes.Buffer
+		w := gzip.NewWriter(&gz)
+		_, _ = w.Write(buf)
+		_ = w.Close()
+		return &gz
+	}
+	table := map[string]struct {
+		r     io.Reader
+		stype string
+		p     Pprof
+		err   error
+	}{
+		"empty profile should be decoded to empty pprof": {
+			r: bytes.NewReader(nil),
+			p: Pprof{Lines: map[string]map[int]int64{}},
+		},
+		"raw profile should be decoded to expected pprof": {
+			r: bytes.NewReader(profile(0)),
+			p: Pprof{
+				Lines: map[string]map[int]int64{
+					"/build/src/svc/pkg/file.go": {
+						10: 1100,
+						20: 200,
+					},
+					"/build/src/svc/other/file.go": {
+						40: 300,
+					},
+				},
+				Sample: "cpu",
+			},
+		},
+		"gzipped profile should be decoded to expected pprof": {
+			r: gzipped(profile(0)),
+			p: Pprof{
+				Lines: map[string]map[int]int64{
+					"/build/src/svc/pkg/file.go": {
+						10: 1100,
+						20: 200,
+					},
+					"/build/src/svc/other/file.go": {
+						40: 300,
+					},
+				},
+				Sample: "cpu",
+			},
+		},
+		"profile with default sample type should be decoded to expected pprof": {
+			r: bytes.NewReader(profile(1)),
+			p: Pprof{
+				Lines: map[string]map[int]int64{
+					"/build/src/svc/pkg/file.go": {
+						10: 11,
+						20: 2,
+					},
+					"/build/src/svc/other/file.go": {
+						40: 3,
+					},
+				},
+				Sample: "samples",
+			},
+		},
+		"profile with provided sample type should be decoded to expected pprof": {
+			r:     bytes.NewReader(profile(3)),
+			stype: "samples",
+			p: Pprof{
+				Lines: map[string]map[int]int64{
+					"/build/src/svc/pkg/file.go": {
+						10: 11,
+						20: 2,
+					},
+					"/build/src/svc/other/file.go": {
+						40: 3,
+					},
+				},
+				Sample: "samples",
+			},
+		},
+		"profile without provided sample type should return sample type error": {
+			r:     bytes.NewReader(profile(0)),
+			stype: "alloc_space",
+			err:   errors.New(`pprof profile has no sample type "alloc_space"`),
+		},
+		"invalid profile should return decode error": {
+			r:   bytes.NewReader([]byte{0x0a, 0x10, 0x01}),
+			err: errors.New("pprof profile has invalid length delimited field"),
+		},
+		"invalid gzipped profile should return unzip error": {
+			r:   bytes.NewReader([]byte{0x1f, 0x8b, 0x00}),
+			err: io.ErrUnexpectedEOF,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			p, err := NewPprof(tcase.r, tcase.stype)
+			// check
+			if !reflect.DeepEqual(p, tcase.p) {
+				t.Errorf("actual %v doesn't equal to expected %v", p, tcase.p)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestPprofWeight(t *testing.T) {
+	// prepare
+	p := Pprof{
+		Lines: map[string]map[int]int64{
+			"/build/src/svc/pkg/file.go": {
+				10: 500,
+			},
+			"/build/src/lib/pkg/file.go": {
+				10: 7,
+			},
+			"/build/src/svc/other/file.go": {
+				10: 300,
+			},
+		},
+	}
+	table := map[string]struct {
+		file   string
+		line   int
+		weight int64
+	}{
+		"file with the same directory and name should have expected weight": {
+			file:   "/home/user/svc/pkg/file.go",
+			line:   10,
+			weight: 500,
+		},
+		"file with the same directory and name should have no weight on other line": {
+			file: "/home/user/svc/pkg/file.go",
+			line: 11,
+		},
+		"file with the longest common path suffix should have expected weight": {
+			file:   "/home/user/lib/pkg/file.go",
+			line:   10,
+			weight: 7,
+		},
+		"file with the same directory and name in other directory should have expected weight": {
+			file:   "/home/user/app/other/file.go",
+			line:   10,
+			weight: 300,
+		},
+		"file with ambiguous directory and name should have no weight": {
+			file: "/home/user/app/pkg/file.go",
+			line: 10,
+		},
+		"file with other directory should have no weight": {
+			file: "/home/user/svc/cmd/file.go",
+			line: 10,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			weight := p.Weight(tcase.file, tcase.line)
+			// check
+			if weight != tcase.weight {
+				t.Errorf("actual %v doesn't equal to expected %v", weight, tcase.weight)
+			}
+		})
+	}
+}
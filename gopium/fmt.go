@@ -3,6 +3,7 @@ package gopium
 import (
 	"context"
 	"go/ast"
+	"go/types"
 )
 
 // Bytes defines abstraction for formatting
@@ -21,5 +22,6 @@ type Diff func(Categorized, Categorized) ([]byte, error)
 // Apply defines abstraction for
 // formatting original ast package by
 // applying custom action accordingly to
-// provided categorized collection
-type Apply func(context.Context, *ast.Package, Locator, Categorized) (*ast.Package, error)
+// provided type checked package
+// and categorized collection
+type Apply func(context.Context, *ast.Package, *types.Package, Locator, Categorized) (*ast.Package, error)
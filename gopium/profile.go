@@ -0,0 +1,16 @@
+package gopium
+
+// Profile defines abstraction for
+// source code lines execution profile
+// that exposes weight of single file line
+type Profile interface {
+	Weight(file string, line int) int64
+}
+
+// SampleProfile defines optional profile abstraction
+// that also exposes profile sample type
+// e.g. cpu or alloc_space
+type SampleProfile interface {
+	Profile
+	SampleType() string
+}
@@ -18,3 +18,29 @@ type StrategyName string
 type StrategyBuilder interface {
 	Build(...StrategyName) (Strategy, error)
 }
+
+// NestedStrategy defines optional strategy abstraction
+// that requires package local nested structures
+// to be applied before structures that contain them
+type NestedStrategy interface {
+	Strategy
+	Nested() bool
+}
+
+// ArchStrategy defines optional strategy abstraction
+// that requires structure fields sizes, aligns and ptrs
+// for list of compiler/arch targets to be exposed
+// in addition to default target ones
+type ArchStrategy interface {
+	Strategy
+	Archs() []string
+}
+
+// UsageStrategy defines optional strategy abstraction
+// that requires package wide fields usages
+// e.g. atomic accesses, co-accesses or profile heats
+// to be collected before structures are exposed
+type UsageStrategy interface {
+	Strategy
+	Usages() bool
+}
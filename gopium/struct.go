@@ -1,25 +1,52 @@
 package gopium
 
 // Field defines single structure field
-// data transfer object abstraction
+// data transfer object abstraction,
+// internal usages analysis data
+// is excluded from serialized output
 type Field struct {
-	Name     string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Type     string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Size     int64    `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Align    int64    `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Ptr      int64    `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Tag      string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Exported bool     `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Embedded bool     `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Doc      []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Comment  []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 122 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 106 bytes; - 🌺 gopium @1pkg
+	Name       string     `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Type       string     `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Size       int64      `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Align      int64      `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Ptr        int64      `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Tag        string     `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Exported   bool       `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Embedded   bool       `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Atomic     bool       `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force" json:"-" xml:"-"`
+	Doc        []string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Comment    []string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Archs      []Arch     `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force" json:"-" xml:"-"`
+	Affinities []Affinity `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force" json:"-" xml:"-"`
+	Heat       int64      `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force" json:"-" xml:"-"`
+} // struct size: 179 bytes; struct align: 8 bytes; struct aligned size: 184 bytes; struct ptr scan size: 155 bytes; - 🌺 gopium @1pkg
+
+// Arch defines single structure field
+// size, align and ptr data transfer object
+// for specific compiler and arch target
+type Arch struct {
+	Target string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Size   int64  `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Align  int64  `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Ptr    int64  `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 40 bytes; struct align: 8 bytes; struct aligned size: 40 bytes; struct ptr scan size: 8 bytes; - 🌺 gopium @1pkg
+
+// Affinity defines single structure field
+// co-access data transfer object
+// with number of functions accessing
+// both the field and named field
+type Affinity struct {
+	Name  string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Count int64  `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 24 bytes; struct align: 8 bytes; struct aligned size: 24 bytes; struct ptr scan size: 8 bytes; - 🌺 gopium @1pkg
 
 // Struct defines single structure
-// data transfer object abstraction
+// data transfer object abstraction,
+// importance is serialized only if it's set
 type Struct struct {
-	Name    string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Doc     []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Comment []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-	Fields  []Field  `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 88 bytes; struct align: 8 bytes; struct aligned size: 88 bytes; struct ptr scan size: 72 bytes; - 🌺 gopium @1pkg
+	Name       string   `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Doc        []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Comment    []string `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Fields     []Field  `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force"`
+	Importance int64    `gopium:"filter_pads,struct_annotate_comment,add_tag_group_force" json:",omitempty" xml:",omitempty"`
+} // struct size: 96 bytes; struct align: 8 bytes; struct aligned size: 96 bytes; struct ptr scan size: 72 bytes; - 🌺 gopium @1pkg
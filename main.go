@@ -30,6 +30,8 @@ var (
 	pbflags []string
 	// gopium walker vars
 	wregex   string
+	wprofile string
+	wptype   string
 	wdeep    bool
 	wbackref bool
 	// gopium printer vars
@@ -70,14 +72,27 @@ Gopium provides next walkers:
  - ast_go_tree (directly syncs result as go code to copy package)
  - ast_std (prints result as go code to stdout)
  - ast_gopium (directly syncs result as go code to copy gopium files)
+ - soa_std (prints generated struct of arrays companion types for results as go code to stdout)
+ - soa_go (writes generated struct of arrays companion types for results as go code to soa.go files
+	alongside original files)
  - file_json (prints json encoded results to single file inside package directory)
  - file_xml (prints xml encoded results to single file inside package directory)
  - file_csv (prints csv encoded results to single file inside package directory)
  - file_md_table (prints markdown table encoded results to single file inside package directory)
  - size_align_file_md_table (prints markdown encoded table of sizes and aligns difference for results to single file
 	inside package directory)
+ - size_align_importance_file_md_table (prints markdown encoded table of sizes and aligns difference
+	for results ranked by structs allocation sites importances to single file inside package directory)
  - fields_file_html_table (prints html encoded table of fields difference for results to single file
 	inside package directory)
+ - heap_file_json (prints json encoded results ranked by heap bytes saved accordingly to heap profile
+	to single file inside package directory)
+ - heap_file_xml (prints xml encoded results ranked by heap bytes saved accordingly to heap profile
+	to single file inside package directory)
+ - heap_file_csv (prints csv encoded results ranked by heap bytes saved accordingly to heap profile
+	to single file inside package directory)
+ - heap_file_md_table (prints markdown table encoded results ranked by heap bytes saved accordingly to heap profile
+	to single file inside package directory)
 
 Gopium provides next strategies:
 
@@ -85,6 +100,22 @@ Gopium provides next strategies:
 	on different groups and then combine results in single struct result)
  - memory_pack (rearranges structure fields to obtain optimal memory utilization)
  - memory_unpack (rearranges structure field list to obtain inflated memory utilization)
+ - memory_pack_optimal (rearranges structure fields to obtain minimal possible memory utilization by searching
+	through fields layouts, falls back to the best found layout if default search budget is exhausted)
+ - memory_pack_optimal_budget_{{uint}} (rearranges structure fields to obtain minimal possible memory utilization
+	by searching through fields layouts, falls back to the best found layout if provided search budget is exhausted)
+ - memory_pack_minimal_moves (rearranges structure fields to obtain memory_pack structure size by moving as few fields
+	as possible from their original positions, annotates number of moved fields in structure comment)
+ - memory_pack_minimal_moves_bytes_{{uint}} (rearranges structure fields to fit structure into provided bytes size
+	by moving as few fields as possible from their original positions, annotates number of moved fields in structure comment;
+	falls back to memory_pack layout and annotates unreached size if structure can't fit into provided bytes size)
+ - memory_pack_deep (rearranges structure fields to obtain optimal memory utilization like memory_pack, but first
+	applies the strategy to package local structures nested by value and then reevaluates parents with their final sizes)
+ - memory_pack_arch(targets=[{{string}},...],mode=sum|max,weights=[{{uint}},...]) (rearranges structure fields
+	to obtain single layout suitable for all provided arch or compiler/arch targets at once, searches layout
+	minimizing weighted sum or worst case of targets structure sizes)
+ - pointer_scan_minimize (rearranges structure fields to obtain minimal gc pointer scan size by placing pointerful
+	fields first, never increases structure size obtained by memory_pack)
  - cache_rounding_cpu_l1_discrete (fits structure into cpu cache line #1 by adding bottom partial rounding cpu cache padding)
  - cache_rounding_cpu_l2_discrete (fits structure into cpu cache line #2 by adding bottom partial rounding cpu cache padding)
  - cache_rounding_cpu_l3_discrete (fits structure into cpu cache line #3 by adding bottom partial rounding cpu cache padding)
@@ -95,6 +126,19 @@ Gopium provides next strategies:
  - cache_rounding_cpu_l3_full (fits structure into full cpu cache line #3 by adding bottom rounding cpu cache padding)
  - cache_rounding_bytes_{{uint}}_full (fits structure into full provided number of bytes by adding bottom rounding
 	bytes cache padding)
+ - cache_rounding(line={{uint}}|bytes={{uint}},mode=discrete|full) (fits structure into provided cpu cache line
+	or provided number of bytes by adding bottom partial or full rounding cache padding, discrete mode is used by default)
+ - size_class_fit(mode=fit|pad) (removes explicit paddings or packs structure only if it drops structure
+	into lower go allocator size class, pad mode also pads structure up to its size class boundary,
+	structure size class is noted in structure comment, fit mode is used by default)
+ - cache_line_no_straddle_cpu_l1 (prevents structure fields from crossing cpu cache line #1 boundaries by moving
+	next fitting fields forward or by adding minimal cpu cache line #1 paddings)
+ - cache_line_no_straddle_cpu_l2 (prevents structure fields from crossing cpu cache line #2 boundaries by moving
+	next fitting fields forward or by adding minimal cpu cache line #2 paddings)
+ - cache_line_no_straddle_cpu_l3 (prevents structure fields from crossing cpu cache line #3 boundaries by moving
+	next fitting fields forward or by adding minimal cpu cache line #3 paddings)
+ - cache_line_no_straddle_bytes_{{uint}} (prevents structure fields from crossing provided number of bytes lines boundaries
+	by moving next fitting fields forward or by adding minimal bytes paddings)
  - false_sharing_cpu_l1 (guards structure from false sharing by adding extra cpu cache line #1 paddings
 	for each structure field)
  - false_sharing_cpu_l2 (guards structure from false sharing by adding extra cpu cache line #1 paddings
@@ -103,6 +147,16 @@ Gopium provides next strategies:
 	for each structure field)
 - false_sharing_bytes_{{uint}} (guards structure from false sharing by adding extra provided number of bytes paddings
 	for each structure field)
+ - false_sharing_isolate_cpu_l1 (guards only fields marked by gopium:"isolate" tag marker from false sharing
+	by isolating them on separate cpu cache line #1, the rest of fields are kept packed together)
+ - false_sharing_isolate_cpu_l2 (guards only fields marked by gopium:"isolate" tag marker from false sharing
+	by isolating them on separate cpu cache line #2, the rest of fields are kept packed together)
+ - false_sharing_isolate_cpu_l3 (guards only fields marked by gopium:"isolate" tag marker from false sharing
+	by isolating them on separate cpu cache line #3, the rest of fields are kept packed together)
+ - false_sharing_isolate_bytes_{{uint}} (guards only fields marked by gopium:"isolate" tag marker from false sharing
+	by isolating them on separate provided number of bytes lines, the rest of fields are kept packed together)
+ - atomic_align_64 (moves 64-bit fields used by sync/atomic functions inside the package to the top of structure
+	to keep them 64-bit aligned on 32-bit target architectures)
  - separate_padding_system_alignment_top (separates structure with extra system alignment padding by adding
 	the padding at the top)
 - separate_padding_system_alignment_bottom (separates structure with extra system alignment padding by adding
@@ -127,6 +181,39 @@ Gopium provides next strategies:
 	missing paddings for each field)
  - explicit_paddings_type_natural (explicitly aligns each structure field to max type alignment padding by adding
 	missing paddings for each field)
+ - hot_cold_split (moves fields marked by gopium:"cold" tag marker to generated companion structure
+	referenced by pointer field, ast walkers rewrite all package selectors accordingly)
+ - heat_placement_cpu_l1 (packs fields split to tiers by gopium:"hot", gopium:"warm", gopium:"cold"
+	or gopium:"heat:N" tag markers from the hottest tier to the coldest one, tiers are moved to next
+	cpu cache line #1 if they span fewer lines there)
+ - heat_placement_cpu_l2 (packs fields split to tiers by gopium:"hot", gopium:"warm", gopium:"cold"
+	or gopium:"heat:N" tag markers from the hottest tier to the coldest one, tiers are moved to next
+	cpu cache line #2 if they span fewer lines there)
+ - heat_placement_cpu_l3 (packs fields split to tiers by gopium:"hot", gopium:"warm", gopium:"cold"
+	or gopium:"heat:N" tag markers from the hottest tier to the coldest one, tiers are moved to next
+	cpu cache line #3 if they span fewer lines there)
+ - heat_placement_bytes_{{uint}} (packs fields split to tiers by gopium:"hot", gopium:"warm", gopium:"cold"
+	or gopium:"heat:N" tag markers from the hottest tier to the coldest one, tiers are moved to next
+	provided number of bytes lines if they span fewer lines there)
+ - affinity_order (clusters fields accessed together by the same package functions on cpu cache line #1,
+	clusters are packed internally and moved to next cache line if they span fewer lines there,
+	fields without affinity are packed after all clusters)
+ - bool_bitset (collapses unexported bool fields marked by gopium:"bitset" tag marker to single flags field,
+	ast walkers generate flags getter and setter methods and rewrite all package selectors accordingly)
+ - bool_bitset_threshold_{{uint}} (collapses all unexported bool fields to single flags field if their number reaches
+	provided threshold, ast walkers generate flags getter and setter methods and rewrite all package selectors accordingly)
+ - record_original_order (records current structure fields order in gopium:"order:N" tag markers,
+	already recorded markers are kept, so the very first order is preserved)
+ - restore_original_order (restores structure fields order recorded in gopium:"order:N" tag markers
+	and removes the markers, fields without markers follow the field they currently follow)
+ - if_size_gt_{{uint}}(...) (applies inner comma separated strategies only to structures
+	with aligned size greater than provided limit, other structures are not changed)
+ - if_fields_gt_{{uint}}(...) (applies inner comma separated strategies only to structures
+	with number of fields greater than provided limit, other structures are not changed)
+ - if_name_match(regex, ...) (applies inner comma separated strategies only to structures
+	with name matching provided regex, other structures are not changed)
+ - first_smaller(... | ...) (applies each pipe separated alternative of comma separated strategies
+	to original structure independently and picks the first result with the smallest aligned size)
  - add_tag_group_soft (adds gopium fields tags annotation if no previous annotation found)
  - add_tag_group_force (adds gopium fields tags annotation if previous annotation found overwrites it)
  - add_tag_group_discrete (discretely adds gopium fields tags annotation if no previous annotation found)
@@ -137,11 +224,33 @@ Gopium provides next strategies:
  - fields_annotate_comment adds align and size comment annotation for each structure field)
  - struct_annotate_doc (adds aggregated align and size doc annotation for structure)
  - struct_annotate_comment (adds aggregated align and size comment annotation for structure)
+ - fields_annotate_offsets_doc (adds align, size, offset, padding before and cpu cache line #1 indexes
+	doc annotation for each structure field)
+ - fields_annotate_offsets_comment (adds align, size, offset, padding before and cpu cache line #1 indexes
+	comment annotation for each structure field)
+ - struct_annotate_report (adds structure waste report doc annotation with padding bytes, padding holes,
+	spanned cpu cache lines #1, memory_pack size and go allocator size class)
  - name_lexicographical_ascending (sorts fields accordingly to their names in ascending order)
  - name_lexicographical_descending (sorts fields accordingly to their names descending order)
  - type_lexicographical_ascending (sorts fields accordingly to their types in ascending order)
  - type_lexicographical_descending (sorts fields accordingly to their types in descending order)
+ - name_lexicographical(order=ascending|descending) (sorts fields accordingly to their names in provided order,
+	ascending order is used by default)
+ - type_lexicographical(order=ascending|descending) (sorts fields accordingly to their types in provided order,
+	ascending order is used by default)
+ - size_ascending (sorts fields accordingly to their sizes in ascending order)
+ - size_descending (sorts fields accordingly to their sizes in descending order)
+ - align_ascending (sorts fields accordingly to their aligns in ascending order)
+ - align_descending (sorts fields accordingly to their aligns in descending order)
+ - exported_first (sorts exported fields before unexported fields)
+ - exported_last (sorts exported fields after unexported fields)
+ - embedded_first (sorts embedded fields before not embedded fields)
+ - embedded_last (sorts embedded fields after not embedded fields)
  - filter_pads (filters out all structure padding fields)
+ - filter_name(regexp={{string}}|names=[{{string}},...]) (filters out all structure fields which names match
+	provided regexp or exactly match one of provided names)
+ - filter_type(regexp={{string}}|names=[{{string}},...]) (filters out all structure fields which types match
+	provided regexp or exactly match one of provided types)
  - ignore (does nothing by returning original structure)
 
 Notes:
@@ -149,6 +258,8 @@ Notes:
  - process_tag_group currently supports only next fields tags annotation formats:
   - gopium:"stg,stg,stg" processed as default group
   - gopium:"group:def;stg,stg,stg" processed as named group
+ - strategies names could accept arguments in name(key=value,key=value) form, where value is either
+	bare value, go double quoted string or list of values [value,value], e.g. filter_type(regexp="^sync\\.").
  - by specifying tag_type you can automatically generate fields tags annotation suitable for process_tag_group.
  - add_tag_* strategies just add list of applied transformations to structure fields tags and NOT change results of
 	other strategies, you can execute process_tag_group strategy afterwards to reuse saved strategies list.
@@ -169,6 +280,8 @@ Notes:
 				// gopium walker vars
 				args[0], // single walker
 				wregex,
+				wprofile,
+				wptype,
 				wdeep,
 				wbackref,
 				args[2:], // strategies slice
@@ -253,6 +366,31 @@ Gopium walker regexp, regexp that defines which structures are subjects for visi
 Visiting is done only if structure name matches the regexp.
 		`,
 	)
+	// set walker_profile flag
+	cli.Flags().StringVarP(
+		&wprofile,
+		"walker_profile",
+		"o",
+		"",
+		`
+Gopium walker profile, path to gzipped or raw pprof cpu or heap profile of the package.
+Profile samples of source lines accessing structures fields are summed up to fields heats.
+Fields heats are used by heat_placement_* strategies for fields without heat tag markers.
+Structs allocations heap profile samples are used by heap_file_* walkers,
+which require heap profile with alloc_space or inuse_space sample type.
+		`,
+	)
+	// set walker_profile_sample_type flag
+	cli.Flags().StringVarP(
+		&wptype,
+		"walker_profile_sample_type",
+		"y",
+		"",
+		`
+Gopium walker profile sample type, pprof profile sample type which values are used, e.g. alloc_space or inuse_space.
+By default profile default sample type or the last sample type is used.
+		`,
+	)
 	// set walker_deep flag
 	cli.Flags().BoolVarP(
 		&wdeep,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/build"
 	"go/parser"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -44,7 +45,9 @@ func NewCli(
 	bflags []string,
 	// gopium walker vars
 	walker,
-	regex string,
+	regex,
+	profile,
+	profiletype string,
 	deep,
 	backref bool,
 	stgs []string,
@@ -97,6 +100,21 @@ func NewCli(
 	if err != nil {
 		return nil, fmt.Errorf("can't compile such regexp %v", err)
 	}
+	// read profile only if
+	// profile path has been provided
+	var prof gopium.Profile
+	if profile != "" {
+		f, err := os.Open(profile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read such profile %v", err)
+		}
+		defer f.Close()
+		pprof, err := fmtio.NewPprof(f, profiletype)
+		if err != nil {
+			return nil, fmt.Errorf("can't read such profile %v", err)
+		}
+		prof = pprof
+	}
 	// cast timeout to second duration
 	stimeout := time.Duration(timeout) * time.Second
 	// set up visitor
@@ -109,6 +127,7 @@ func NewCli(
 		Parser:  xp,
 		Exposer: m,
 		Printer: p,
+		Profile: prof,
 		Deep:    deep,
 		Bref:    backref,
 	}
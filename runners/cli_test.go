@@ -41,6 +41,8 @@ func TestNewCli(t *testing.T) {
 		// walker vars
 		walker  string
 		regex   string
+		profile string
+		ptype   string
 		deep    bool
 		backref bool
 		stgs    []string
@@ -252,6 +254,36 @@ func TestNewCli(t *testing.T) {
 			// test vars
 			err: errors.New("can't compile such regexp error parsing regexp: missing closing ]: `[`"),
 		},
+		"new cli should return error on profile read error": {
+			// target platform vars
+			compiler:  "gc",
+			arch:      "amd64",
+			cpucaches: []int{2, 4, 8},
+			// package parser vars
+			pkg:    "test-pkg",
+			path:   "test-path",
+			benvs:  []string{},
+			bflags: []string{},
+			// walker vars
+			walker:  "test-w",
+			regex:   `.*`,
+			profile: "test-profile",
+			deep:    true,
+			backref: true,
+			stgs:    []string{"test-stg"},
+			// printer vars
+			indent:   4,
+			tabwidth: 4,
+			usespace: true,
+			// global vars
+			timeout: 5,
+			// test vars
+			err: errors.New(tests.OnOS(
+				"windows",
+				"can't read such profile open test-profile: The system cannot find the file specified.",
+				"can't read such profile open test-profile: no such file or directory",
+			).(string)),
+		},
 	}
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
@@ -266,6 +298,8 @@ func TestNewCli(t *testing.T) {
 				tcase.bflags,
 				tcase.walker,
 				tcase.regex,
+				tcase.profile,
+				tcase.ptype,
 				tcase.deep,
 				tcase.backref,
 				tcase.stgs,
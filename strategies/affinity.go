@@ -0,0 +1,157 @@
+package strategies
+
+import (
+	"context"
+	"sort"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of affinity presets
+var (
+	affinityl1 = affinity{}
+)
+
+// affinity defines strategy implementation
+// that clusters structure fields accessed together
+// by the same package functions on the same cpu cache line #1,
+// clusters are built greedily starting from the field
+// with the biggest total affinity and extended by fields
+// with the biggest affinity to the cluster while
+// the packed cluster fits into single cache line,
+// clusters are packed internally and moved to the next
+// cache line if it reduces number of cache lines they span,
+// fields without any affinity are packed after all clusters
+type affinity struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Curator erich affinity strategy with curator instance
+func (stg affinity) Curator(curator gopium.Curator) affinity {
+	stg.curator = curator
+	return stg
+}
+
+// Apply affinity implementation
+func (stg affinity) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// check that struct has fields
+	// and cache line size is valid
+	flen, cachel := len(r.Fields), stg.curator.SysCache(1)
+	if flen == 0 || cachel <= 0 {
+		return r, ctx.Err()
+	}
+	// collect fields affinities matrix
+	// and total affinities of fields
+	names := make(map[string]int, flen)
+	for i, f := range r.Fields {
+		names[f.Name] = i
+	}
+	weights := make([][]int64, flen)
+	totals := make([]int64, flen)
+	for i, f := range r.Fields {
+		weights[i] = make([]int64, flen)
+		for _, a := range f.Affinities {
+			if j, ok := names[a.Name]; ok && j != i {
+				weights[i][j] += a.Count
+				totals[i] += a.Count
+			}
+		}
+	}
+	// build fields clusters one by one
+	used := make([]bool, flen)
+	var clusters [][]gopium.Field
+	for {
+		// manage context actions
+		// in case of cancelation
+		// stop execution
+		select {
+		case <-ctx.Done():
+			return o, ctx.Err()
+		default:
+		}
+		// pick the seed field with
+		// the biggest total affinity
+		seed := -1
+		for i := range r.Fields {
+			if !used[i] && totals[i] > 0 && (seed < 0 || totals[i] > totals[seed]) {
+				seed = i
+			}
+		}
+		if seed < 0 {
+			break
+		}
+		used[seed] = true
+		idxs := []int{seed}
+		cluster := []gopium.Field{r.Fields[seed]}
+		// extend the cluster with fields
+		// with the biggest affinity to it
+		// while it fits into cache line
+		for {
+			best, bweight := -1, int64(0)
+			for j := range r.Fields {
+				if used[j] {
+					continue
+				}
+				var weight int64
+				for _, i := range idxs {
+					weight += weights[i][j]
+				}
+				if weight <= bweight {
+					continue
+				}
+				candidate := apack(append(cluster[:len(cluster):len(cluster)], r.Fields[j]))
+				if size, _, _ := collections.SizeAlignPtr(gopium.Struct{Fields: candidate}); size <= cachel {
+					best, bweight = j, weight
+				}
+			}
+			if best < 0 {
+				break
+			}
+			used[best] = true
+			idxs = append(idxs, best)
+			cluster = append(cluster, r.Fields[best])
+		}
+		clusters = append(clusters, apack(cluster))
+	}
+	// place all clusters one by one
+	fields := make([]gopium.Field, 0, flen)
+	for _, cluster := range clusters {
+		// move the cluster to the next cache line
+		// only if it spans fewer cache lines there
+		if pad := offset(fields) % cachel; pad > 0 {
+			padded := append(fields[:len(fields):len(fields)], collections.PadField(cachel-pad))
+			if hlines(padded, cluster, cachel) < hlines(fields, cluster, cachel) {
+				fields = padded
+			}
+		}
+		fields = append(fields, cluster...)
+	}
+	// place the rest of fields
+	rest := make([]gopium.Field, 0, flen)
+	for i, f := range r.Fields {
+		if !used[i] {
+			rest = append(rest, f)
+		}
+	}
+	fields = append(fields, apack(rest)...)
+	// update resulted fields
+	r.Fields = fields
+	return r, ctx.Err()
+}
+
+// apack packs provided fields in place
+// and returns them back
+func apack(fields []gopium.Field) []gopium.Field {
+	sort.SliceStable(fields, func(i, j int) bool {
+		return packless(fields[i], fields[j])
+	})
+	return fields
+}
+
+// Usages affinity implementation
+func (stg affinity) Usages() bool {
+	return true
+}
@@ -0,0 +1,525 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestAffinity(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		c   gopium.Curator
+		ctx context.Context
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to empty struct": {
+			c:   mocks.Maven{SCache: []int64{16}},
+			ctx: context.Background(),
+		},
+		"non empty struct should be applied to itself on invalid cache line": {
+			c:   mocks.Maven{},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "b",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "x",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "y",
+								Count: 3,
+							},
+						},
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "b",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "x",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "y",
+								Count: 3,
+							},
+						},
+					},
+				},
+			},
+		},
+		"non empty struct without affinities should be applied to packed struct": {
+			c:   mocks.Maven{SCache: []int64{16}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "t1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "t2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "t3",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "t2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "t3",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "t1",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to separate clusters on cache line overflow": {
+			c:   mocks.Maven{SCache: []int64{8}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "b",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "b",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "a",
+								Count: 1,
+							},
+						},
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "b",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "b",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "a",
+								Count: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to expected clustered struct": {
+			c:   mocks.Maven{SCache: []int64{16}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "b",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "x",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "y",
+								Count: 3,
+							},
+						},
+					},
+					{
+						Name:  "b",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "a",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "y",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "x",
+								Count: 3,
+							},
+						},
+					},
+					{
+						Name:  "z",
+						Size:  2,
+						Align: 2,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "x",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "y",
+								Count: 3,
+							},
+						},
+					},
+					{
+						Name:  "y",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "x",
+								Count: 3,
+							},
+						},
+					},
+					{
+						Name:  "a",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "b",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "b",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "a",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "z",
+						Size:  2,
+						Align: 2,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to expected clustered struct with cache line padding": {
+			c:   mocks.Maven{SCache: []int64{16}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "c1",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "c2",
+								Count: 2,
+							},
+						},
+					},
+					{
+						Name:  "d1",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "d2",
+								Count: 1,
+							},
+							{
+								Name:  "d3",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "c2",
+						Size:  2,
+						Align: 2,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "c1",
+								Count: 2,
+							},
+						},
+					},
+					{
+						Name:  "d2",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "d1",
+								Count: 1,
+							},
+							{
+								Name:  "d3",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "d3",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "d1",
+								Count: 1,
+							},
+							{
+								Name:  "d2",
+								Count: 1,
+							},
+						},
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "c1",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "c2",
+								Count: 2,
+							},
+						},
+					},
+					{
+						Name:  "c2",
+						Size:  2,
+						Align: 2,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "c1",
+								Count: 2,
+							},
+						},
+					},
+					collections.PadField(10),
+					{
+						Name:  "d1",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "d2",
+								Count: 1,
+							},
+							{
+								Name:  "d3",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "d2",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "d1",
+								Count: 1,
+							},
+							{
+								Name:  "d3",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "d3",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "d1",
+								Count: 1,
+							},
+							{
+								Name:  "d2",
+								Count: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to itself on canceled context": {
+			c:   mocks.Maven{SCache: []int64{16}},
+			ctx: cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "b",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "b",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "a",
+								Count: 1,
+							},
+						},
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  8,
+						Align: 8,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "b",
+								Count: 1,
+							},
+						},
+					},
+					{
+						Name:  "b",
+						Size:  4,
+						Align: 4,
+						Affinities: []gopium.Affinity{
+							{
+								Name:  "a",
+								Count: 1,
+							},
+						},
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			aff := affinityl1.Curator(tcase.c)
+			// exec
+			r, err := aff.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestAligns(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		aligns aligns
+		ctx    context.Context
+		o      gopium.Struct
+		r      gopium.Struct
+		err    error
+	}{
+		"empty struct should be applied to empty struct": {
+			aligns: alignsasc,
+			ctx:    context.Background(),
+		},
+		"non empty struct should be applied to itself": {
+			aligns: alignsasc,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to itself on canceled context": {
+			aligns: alignsdesc,
+			ctx:    cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"mixed align struct should be applied to stable sorted struct asc": {
+			aligns: alignsasc,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Align: 4,
+					},
+					{
+						Name:  "test4",
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test4",
+						Align: 1,
+					},
+					{
+						Name:  "test1",
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Align: 8,
+					},
+				},
+			},
+		},
+		"mixed align struct should be applied to stable sorted struct desc": {
+			aligns: alignsdesc,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Align: 4,
+					},
+					{
+						Name:  "test4",
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Align: 8,
+					},
+					{
+						Name:  "test1",
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Align: 4,
+					},
+					{
+						Name:  "test4",
+						Align: 1,
+					},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.aligns.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
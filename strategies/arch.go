@@ -0,0 +1,333 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of arch presets
+var (
+	pckarchsum = arch{}
+	pckarchmax = arch{max: true}
+)
+
+// arch defines strategy implementation
+// that rearranges structure fields
+// to obtain single layout suitable for
+// list of compiler/arch targets at once,
+// candidate layouts (original, default pack
+// and pack for each target) are evaluated
+// against all targets fields sizes and aligns
+// and the best of them seeds budgeted branch and bound
+// search of the layout minimizing weighted sum
+// (or worst case) of targets sizes,
+// first found layout wins on ties
+type arch struct {
+	targets []string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	weights []uint   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	max     bool     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [15]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
+
+// Targets erich arch strategy with compiler/arch targets
+func (stg arch) Targets(targets ...string) arch {
+	stg.targets = targets
+	return stg
+}
+
+// Weights erich arch strategy with targets weights
+func (stg arch) Weights(weights ...uint) arch {
+	stg.weights = weights
+	return stg
+}
+
+// Apply arch implementation
+func (stg arch) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// in case there are no targets or fields
+	// just return result back
+	if len(stg.targets) == 0 || len(r.Fields) == 0 {
+		return r, ctx.Err()
+	}
+	// collect structure fields
+	// for each target
+	tfields := make([][]gopium.Field, 0, len(stg.targets))
+	for _, target := range stg.targets {
+		fields, err := afields(r, target)
+		if err != nil {
+			return o, err
+		}
+		tfields = append(tfields, fields)
+	}
+	// collect candidate orders: original,
+	// default pack and pack for each target
+	orders := [][]int{aorder(r.Fields, false), aorder(r.Fields, true)}
+	for _, fields := range tfields {
+		orders = append(orders, aorder(fields, true))
+	}
+	// pick the order with the smallest cost
+	var best []int
+	var bcost int64
+	for _, order := range orders {
+		// manage context actions
+		// in case of cancelation
+		// stop execution
+		select {
+		case <-ctx.Done():
+			return o, ctx.Err()
+		default:
+		}
+		if cost := stg.cost(tfields, order); best == nil || cost < bcost {
+			best, bcost = order, cost
+		}
+	}
+	// run bounded layout search
+	// seeded with the best candidate
+	// and use its best found order
+	s := newasearch(stg, tfields, best, bcost, pckopt.budget)
+	s.dfs(make([]int64, len(tfields)))
+	best = s.best
+	fields := make([]gopium.Field, 0, len(r.Fields))
+	for _, i := range best {
+		fields = append(fields, r.Fields[i])
+	}
+	r.Fields = fields
+	return r, ctx.Err()
+}
+
+// Archs arch implementation
+func (stg arch) Archs() []string {
+	return stg.targets
+}
+
+// cost calculates provided fields order cost
+// as either weighted sum or max of targets sizes
+func (stg arch) cost(tfields [][]gopium.Field, order []int) int64 {
+	var cost int64
+	for t, fields := range tfields {
+		ordered := make([]gopium.Field, 0, len(order))
+		for _, i := range order {
+			ordered = append(ordered, fields[i])
+		}
+		size, _, _ := collections.SizeAlignPtr(gopium.Struct{Fields: ordered})
+		cost = stg.acc(cost, t, size)
+	}
+	return cost
+}
+
+// acc accumulates provided target size
+// to either weighted sum or max cost
+func (stg arch) acc(cost int64, t int, size int64) int64 {
+	switch {
+	case stg.max:
+		if size > cost {
+			cost = size
+		}
+	case t < len(stg.weights):
+		cost += size * int64(stg.weights[t])
+	default:
+		cost += size
+	}
+	return cost
+}
+
+// afields returns structure fields copies
+// with sizes, aligns and ptrs of provided target,
+// fields without any target sizes (e.g. pads, split
+// cold fields or bitset flags) are treated as
+// target independent and keep their own sizes
+func afields(st gopium.Struct, target string) ([]gopium.Field, error) {
+	fields := make([]gopium.Field, 0, len(st.Fields))
+	for _, f := range st.Fields {
+		if len(f.Archs) == 0 {
+			fields = append(fields, f)
+			continue
+		}
+		found := false
+		for _, a := range f.Archs {
+			if a.Target == target {
+				f.Size, f.Align, f.Ptr = a.Size, a.Align, a.Ptr
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("field %q in struct %q has no target %q sizes", f.Name, st.Name, target)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// aorder returns either identity or
+// pack fields order for provided fields
+func aorder(fields []gopium.Field, pack bool) []int {
+	order := make([]int, len(fields))
+	for i := range order {
+		order[i] = i
+	}
+	if pack {
+		sort.SliceStable(order, func(i, j int) bool {
+			return packless(fields[order[i]], fields[order[j]])
+		})
+	}
+	return order
+}
+
+// asearch defines branch and bound fields order search
+// that minimizes arch strategy cost of all targets at once,
+// identical on all targets fields are placed in their
+// original relative order to avoid exploring
+// equivalent permutations, search is seeded with
+// provided candidate order and never exceeds its cost
+type asearch struct {
+	memo    map[string]bool  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	tfields [][]gopium.Field `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	used    []bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	order   []int            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	best    []int            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	rsizes  []int64          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	aligns  []int64          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	stg     arch             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	budget  uint             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bcost   int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [24]byte         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 256 bytes; struct align: 8 bytes; struct aligned size: 256 bytes; struct ptr scan size: 184 bytes; - 🌺 gopium @1pkg
+
+// newasearch creates arch search instance
+// from provided targets fields and seed order
+func newasearch(stg arch, tfields [][]gopium.Field, seed []int, cost int64, budget uint) *asearch {
+	s := &asearch{
+		memo:    make(map[string]bool),
+		tfields: tfields,
+		used:    make([]bool, len(seed)),
+		best:    append([]int(nil), seed...),
+		rsizes:  make([]int64, len(tfields)),
+		aligns:  make([]int64, len(tfields)),
+		stg:     stg,
+		budget:  budget,
+		bcost:   cost,
+	}
+	// collect search bounds helpers
+	for t, fields := range tfields {
+		s.aligns[t] = 1
+		for _, f := range fields {
+			s.rsizes[t] += f.Size
+			if f.Align > s.aligns[t] {
+				s.aligns[t] = f.Align
+			}
+		}
+	}
+	return s
+}
+
+// identical checks if two fields are indistinguishable
+// from layout point of view on all targets
+func (s *asearch) identical(i, j int) bool {
+	for _, fields := range s.tfields {
+		fi, fj := fields[i], fields[j]
+		if fi.Size != fj.Size || fi.Align != fj.Align {
+			return false
+		}
+	}
+	return true
+}
+
+// dfs explores orders started from
+// provided targets offsets
+func (s *asearch) dfs(offsets []int64) {
+	// check that search budget
+	// is not exhausted yet
+	if s.budget == 0 {
+		return
+	}
+	s.budget--
+	// in case all fields are placed
+	// check if order is better than best one
+	if len(s.order) == len(s.used) {
+		if cost := s.stg.cost(s.tfields, s.order); cost < s.bcost {
+			s.bcost = cost
+			s.best = append(s.best[:0], s.order...)
+		}
+		return
+	}
+	// calculate lower bound
+	// and prune branch if it
+	// can't be better than best one
+	var lcost int64
+	for t := range s.tfields {
+		lcost = s.stg.acc(lcost, t, collections.Align(offsets[t]+s.rsizes[t], s.aligns[t]))
+	}
+	if lcost >= s.bcost {
+		return
+	}
+	// check if the same state
+	// was already explored
+	key := s.key(offsets)
+	if s.memo[key] {
+		return
+	}
+	s.memo[key] = true
+	// go through all unplaced fields
+	for i := range s.used {
+		if s.used[i] || s.duplicate(i) {
+			continue
+		}
+		// place the field on all targets
+		noffsets := make([]int64, len(offsets))
+		for t, fields := range s.tfields {
+			f := fields[i]
+			noffsets[t] = offsets[t]
+			if f.Align > 0 {
+				noffsets[t] = collections.Align(noffsets[t], f.Align)
+			}
+			noffsets[t] += f.Size
+			s.rsizes[t] -= f.Size
+		}
+		s.used[i] = true
+		s.order = append(s.order, i)
+		s.dfs(noffsets)
+		// revert the field placement
+		s.order = s.order[:len(s.order)-1]
+		s.used[i] = false
+		for t, fields := range s.tfields {
+			s.rsizes[t] += fields[i].Size
+		}
+	}
+}
+
+// duplicate checks if there is unplaced field
+// before provided one identical to it on all targets
+func (s *asearch) duplicate(i int) bool {
+	for j := 0; j < i; j++ {
+		if !s.used[j] && s.identical(i, j) {
+			return true
+		}
+	}
+	return false
+}
+
+// key builds memo key from
+// the current search state
+func (s *asearch) key(offsets []int64) string {
+	var buf strings.Builder
+	for _, offset := range offsets {
+		buf.WriteString(strconv.FormatInt(offset, 10))
+		buf.WriteByte(':')
+	}
+	for _, used := range s.used {
+		if used {
+			buf.WriteByte('1')
+		} else {
+			buf.WriteByte('0')
+		}
+	}
+	return buf.String()
+}
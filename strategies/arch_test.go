@@ -0,0 +1,268 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestArch(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// int64 is differently aligned
+	// on amd64 and 386 targets
+	a := gopium.Field{
+		Name:  "a",
+		Type:  "int64",
+		Size:  8,
+		Align: 8,
+		Archs: []gopium.Arch{
+			{
+				Target: "gc/amd64",
+				Size:   8,
+				Align:  8,
+			},
+			{
+				Target: "gc/386",
+				Size:   8,
+				Align:  4,
+			},
+		},
+	}
+	s := gopium.Field{
+		Name:  "s",
+		Type:  "[3]int32",
+		Size:  12,
+		Align: 4,
+		Archs: []gopium.Arch{
+			{
+				Target: "gc/amd64",
+				Size:   12,
+				Align:  4,
+			},
+			{
+				Target: "gc/386",
+				Size:   12,
+				Align:  4,
+			},
+		},
+	}
+	d := gopium.Field{
+		Name:  "d",
+		Type:  "byte",
+		Size:  1,
+		Align: 1,
+		Archs: []gopium.Arch{
+			{
+				Target: "gc/amd64",
+				Size:   1,
+				Align:  1,
+			},
+			{
+				Target: "gc/386",
+				Size:   1,
+				Align:  1,
+			},
+		},
+	}
+	// unlike a, y is bigger and stricter
+	// aligned on 386 than on amd64 target
+	y := gopium.Field{
+		Name:  "y",
+		Type:  "y",
+		Size:  4,
+		Align: 4,
+		Archs: []gopium.Arch{
+			{
+				Target: "gc/amd64",
+				Size:   4,
+				Align:  4,
+			},
+			{
+				Target: "gc/386",
+				Size:   8,
+				Align:  8,
+			},
+		},
+	}
+	l := gopium.Field{
+		Name:  "l",
+		Type:  "[]int",
+		Size:  24,
+		Align: 8,
+		Ptr:   8,
+		Archs: []gopium.Arch{
+			{
+				Target: "gc/amd64",
+				Size:   24,
+				Align:  8,
+				Ptr:    8,
+			},
+			{
+				Target: "gc/386",
+				Size:   12,
+				Align:  4,
+				Ptr:    4,
+			},
+		},
+	}
+	// pad has no target sizes
+	p := gopium.Field{
+		Name:  "_",
+		Type:  "[3]byte",
+		Size:  3,
+		Align: 1,
+	}
+	table := map[string]struct {
+		arch arch
+		ctx  context.Context
+		o    gopium.Struct
+		r    gopium.Struct
+		err  error
+	}{
+		"empty struct should be applied to empty struct": {
+			arch: pckarchsum.Targets("gc/386"),
+			ctx:  context.Background(),
+		},
+		"non empty struct should be applied to itself without targets": {
+			arch: pckarchsum,
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{d, a},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{d, a},
+			},
+		},
+		"non empty struct should be applied to itself on canceled context": {
+			arch: pckarchsum.Targets("gc/386"),
+			ctx:  cctx,
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{d, a},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{d, a},
+			},
+			err: context.Canceled,
+		},
+		"non empty struct should be applied to expected result on missing target sizes": {
+			arch: pckarchsum.Targets("gc/arm"),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{d, a},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{d, a},
+			},
+			err: errors.New(`field "d" in struct "test" has no target "gc/arm" sizes`),
+		},
+		"non empty struct with pad should be applied to expected result": {
+			arch: pckarchsum.Targets("gc/amd64", "gc/386"),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{d, p, a, s},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{a, s, p, d},
+			},
+		},
+		"non empty struct should be applied to expected searched layout by sum": {
+			arch: pckarchsum.Targets("gc/amd64", "gc/386"),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{d, y, l},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{y, d, l},
+			},
+		},
+		"non empty struct should be applied to itself if original layout is the smallest": {
+			arch: pckarchsum.Targets("gc/amd64", "gc/386"),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{a, s, d},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{a, s, d},
+			},
+		},
+		"non empty struct should be applied to pack layout of single target": {
+			arch: pckarchsum.Targets("gc/amd64"),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{d, a, s},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{a, s, d},
+			},
+		},
+		"non empty struct should be applied to expected layout by sum": {
+			arch: pckarchsum.Targets("gc/amd64", "gc/386"),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{s, a, d},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{a, s, d},
+			},
+		},
+		"non empty struct should be applied to expected layout by weighted sum": {
+			arch: pckarchsum.Targets("gc/amd64", "gc/386").Weights(0, 1),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{s, a, d},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{s, a, d},
+			},
+		},
+		"non empty struct should be applied to expected layout by worst case": {
+			arch: pckarchmax.Targets("gc/amd64", "gc/386"),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{s, a, d},
+			},
+			r: gopium.Struct{
+				Name:   "test",
+				Fields: []gopium.Field{a, s, d},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.arch.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package strategies
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// arg defines single strategy name argument
+// with its position inside strategy name
+type arg struct {
+	key    string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	val    string   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	list   []string `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	pos    int      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	islist bool     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [63]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 40 bytes; - 🌺 gopium @1pkg
+
+// args defines parsed strategy name arguments
+// that follow next grammar:
+//
+//	name  := ident [ '(' [ arg { ',' arg } ] ')' ]
+//	arg   := ident '=' value
+//	value := quoted | list | bare
+//	list  := '[' [ item { ',' item } ] ']'
+//	item  := quoted | bare
+//
+// where quoted is go double quoted string
+// and bare is any sequence of characters except `,()[]="`
+type args struct {
+	used map[string]bool     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	name gopium.StrategyName `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	base string              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	args []arg               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 48 bytes; - 🌺 gopium @1pkg
+
+// parsea parses strategy name to its base and arguments
+func parsea(name gopium.StrategyName) (*args, error) {
+	s := string(name)
+	a := &args{name: name, used: make(map[string]bool)}
+	// names without arguments
+	// are used as is
+	i := strings.IndexByte(s, '(')
+	if i < 0 {
+		a.base = s
+		return a, nil
+	}
+	a.base = s[:i]
+	if !strings.HasSuffix(s, ")") {
+		return nil, a.errorf(len(s), "expected closing parenthesis")
+	}
+	// go through all arguments
+	// until closing parenthesis
+	pos, end := i+1, len(s)-1
+	for pos < end {
+		// read argument key
+		start := pos
+		for pos < end && s[pos] != '=' && s[pos] != ',' {
+			pos++
+		}
+		key := strings.TrimSpace(s[start:pos])
+		if key == "" || pos == end || s[pos] != '=' {
+			return nil, a.errorf(start, "expected argument in key=value form")
+		}
+		for _, ar := range a.args {
+			if ar.key == key {
+				return nil, a.errorf(start, "argument %q is duplicated", key)
+			}
+		}
+		pos++
+		// read argument value or list
+		ar := arg{key: key, pos: pos}
+		if pos < end && s[pos] == '[' {
+			ar.islist = true
+			pos++
+			for pos < end && s[pos] != ']' {
+				item, next, err := a.value(s, pos, end)
+				if err != nil {
+					return nil, err
+				}
+				ar.list = append(ar.list, item)
+				pos = next
+				if pos < end && s[pos] == ',' {
+					pos++
+				}
+			}
+			if pos == end {
+				return nil, a.errorf(pos, "expected closing bracket")
+			}
+			pos++
+		} else {
+			val, next, err := a.value(s, pos, end)
+			if err != nil {
+				return nil, err
+			}
+			ar.val, pos = val, next
+		}
+		a.args = append(a.args, ar)
+		// arguments are separated by comma
+		if pos < end {
+			if s[pos] != ',' {
+				return nil, a.errorf(pos, "expected comma")
+			}
+			pos++
+		}
+	}
+	return a, nil
+}
+
+// value reads single quoted or bare value
+// from provided position, returns value and next position
+func (a *args) value(s string, pos int, end int) (string, int, error) {
+	// quoted values are read until
+	// not escaped closing quote
+	if pos < end && s[pos] == '"' {
+		for next := pos + 1; next < end; next++ {
+			switch s[next] {
+			case '\\':
+				next++
+			case '"':
+				val, err := strconv.Unquote(s[pos : next+1])
+				if err != nil {
+					return "", pos, a.errorf(pos, "invalid quoted value %v", err)
+				}
+				return val, next + 1, nil
+			}
+		}
+		return "", pos, a.errorf(pos, "expected closing quote")
+	}
+	// bare values are read until
+	// any special character
+	next := pos
+	for next < end && !strings.ContainsRune(`,()[]="`, rune(s[next])) {
+		next++
+	}
+	if next == pos {
+		return "", pos, a.errorf(pos, "expected value")
+	}
+	return strings.TrimSpace(s[pos:next]), next, nil
+}
+
+// match checks if strategy name base matches provided base
+func (a *args) match(base gopium.StrategyName) bool {
+	return a.base == string(base)
+}
+
+// get finds argument by its key and marks it as used
+func (a *args) get(key string) (arg, bool) {
+	a.used[key] = true
+	for _, ar := range a.args {
+		if ar.key == key {
+			return ar, true
+		}
+	}
+	return arg{}, false
+}
+
+// uint returns uint argument by its key
+// or provided default if argument is missing
+func (a *args) uint(key string, def uint) (uint, error) {
+	ar, ok := a.get(key)
+	if !ok {
+		return def, nil
+	}
+	if ar.islist {
+		return 0, a.errorf(ar.pos, "argument %q expected uint", key)
+	}
+	val, err := strconv.ParseUint(ar.val, 10, 0)
+	if err != nil {
+		return 0, a.errorf(ar.pos, "argument %q expected uint", key)
+	}
+	return uint(val), nil
+}
+
+// enum returns one of provided options argument by its key
+// or provided default if argument is missing
+func (a *args) enum(key string, def string, opts ...string) (string, error) {
+	ar, ok := a.get(key)
+	if !ok {
+		return def, nil
+	}
+	if !ar.islist {
+		for _, opt := range opts {
+			if ar.val == opt {
+				return opt, nil
+			}
+		}
+	}
+	return "", a.errorf(ar.pos, "argument %q expected one of %s", key, strings.Join(opts, "|"))
+}
+
+// regexp returns regexp argument by its key
+// or nil if argument is missing
+func (a *args) regexp(key string) (*regexp.Regexp, error) {
+	ar, ok := a.get(key)
+	if !ok {
+		return nil, nil
+	}
+	if ar.islist {
+		return nil, a.errorf(ar.pos, "argument %q expected regexp", key)
+	}
+	regex, err := regexp.Compile(ar.val)
+	if err != nil {
+		return nil, a.errorf(ar.pos, "argument %q expected regexp %v", key, err)
+	}
+	return regex, nil
+}
+
+// list returns list argument by its key
+// or nil if argument is missing,
+// single values are treated as single item lists
+func (a *args) list(key string) ([]string, error) {
+	ar, ok := a.get(key)
+	if !ok {
+		return nil, nil
+	}
+	if !ar.islist {
+		return []string{ar.val}, nil
+	}
+	return ar.list, nil
+}
+
+// check verifies that all arguments were used
+// and required arguments are provided
+func (a *args) check(required ...string) error {
+	for _, key := range required {
+		if _, ok := a.get(key); !ok {
+			return a.errorf(len(a.name), "argument %q is required", key)
+		}
+	}
+	for _, ar := range a.args {
+		if !a.used[ar.key] {
+			return a.errorf(ar.pos, "argument %q is unknown", ar.key)
+		}
+	}
+	return nil
+}
+
+// errorf creates strategy name error at provided position
+func (a *args) errorf(pos int, format string, vals ...interface{}) error {
+	return fmt.Errorf("strategy %q at %d %s", a.name, pos, fmt.Sprintf(format, vals...))
+}
+
+// splita splits strategies names list by commas
+// outside of strategies arguments
+func splita(s string) []string {
+	return splits(s, ',')
+}
+
+// splits splits strategies names list by provided separator
+// outside of strategies arguments
+func splits(s string, sep byte) []string {
+	var names []string
+	var depth int
+	var quoted bool
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case c == sep && depth == 0:
+			names = append(names, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(names, s[start:])
+}
@@ -0,0 +1,162 @@
+package strategies
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestParsea(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		name gopium.StrategyName
+		base string
+		args []arg
+		err  error
+	}{
+		"name without arguments should be parsed to base": {
+			name: "memory_pack",
+			base: "memory_pack",
+		},
+		"name with empty arguments should be parsed to base": {
+			name: "memory_pack()",
+			base: "memory_pack",
+		},
+		"name with bare arguments should be parsed to arguments": {
+			name: "cache_rounding(bytes=128,mode=full)",
+			base: "cache_rounding",
+			args: []arg{
+				{key: "bytes", val: "128", pos: 21},
+				{key: "mode", val: "full", pos: 30},
+			},
+		},
+		"name with quoted arguments should be parsed to arguments": {
+			name: `filter_type(regexp="^sync\\.(Mutex|Map)$")`,
+			base: "filter_type",
+			args: []arg{
+				{key: "regexp", val: `^sync\.(Mutex|Map)$`, pos: 19},
+			},
+		},
+		"name with list arguments should be parsed to arguments": {
+			name: `filter_name(names=[a,"b,c"],regexp=[])`,
+			base: "filter_name",
+			args: []arg{
+				{key: "names", list: []string{"a", "b,c"}, pos: 18, islist: true},
+				{key: "regexp", pos: 35, islist: true},
+			},
+		},
+		"name with invalid parenthesis should return error": {
+			name: "cache_rounding(bytes=128",
+			err:  fmt.Errorf(`strategy "cache_rounding(bytes=128" at 24 expected closing parenthesis`),
+		},
+		"name with invalid argument should return error": {
+			name: "cache_rounding(bytes)",
+			err:  fmt.Errorf(`strategy "cache_rounding(bytes)" at 15 expected argument in key=value form`),
+		},
+		"name with duplicated argument should return error": {
+			name: "cache_rounding(bytes=1,bytes=2)",
+			err:  fmt.Errorf(`strategy "cache_rounding(bytes=1,bytes=2)" at 23 argument "bytes" is duplicated`),
+		},
+		"name with invalid value should return error": {
+			name: "cache_rounding(bytes=,mode=full)",
+			err:  fmt.Errorf(`strategy "cache_rounding(bytes=,mode=full)" at 21 expected value`),
+		},
+		"name with invalid quoted value should return error": {
+			name: `filter_type(regexp="^sync)`,
+			err:  fmt.Errorf(`strategy "filter_type(regexp=\"^sync)" at 19 expected closing quote`),
+		},
+		"name with invalid list should return error": {
+			name: `filter_type(names=[a,b)`,
+			err:  fmt.Errorf(`strategy "filter_type(names=[a,b)" at 22 expected closing bracket`),
+		},
+		"name with invalid separator should return error": {
+			name: `filter_type(names=[a]b)`,
+			err:  fmt.Errorf(`strategy "filter_type(names=[a]b)" at 21 expected comma`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			a, err := parsea(tcase.name)
+			// check
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if err != nil {
+				return
+			}
+			if a.base != tcase.base {
+				t.Errorf("actual %v doesn't equal to expected %v", a.base, tcase.base)
+			}
+			if !reflect.DeepEqual(a.args, tcase.args) {
+				t.Errorf("actual %v doesn't equal to expected %v", a.args, tcase.args)
+			}
+		})
+	}
+}
+
+func TestSplita(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		names string
+		r     []string
+	}{
+		"empty names should be split to single empty name": {
+			r: []string{""},
+		},
+		"plain names should be split by commas": {
+			names: "filter_pads,memory_pack",
+			r:     []string{"filter_pads", "memory_pack"},
+		},
+		"names with arguments should be split by commas outside of arguments": {
+			names: `filter_name(names=[a,b],regexp="a,b"),cache_rounding(bytes=64,mode=full),memory_pack`,
+			r:     []string{`filter_name(names=[a,b],regexp="a,b")`, "cache_rounding(bytes=64,mode=full)", "memory_pack"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := splita(tcase.names)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
+
+func TestSplits(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		names string
+		sep   byte
+		r     []string
+	}{
+		"empty names should be split to single empty name": {
+			sep: '|',
+			r:   []string{""},
+		},
+		"plain names should be split by separator": {
+			names: "memory_pack|memory_unpack,filter_pads",
+			sep:   '|',
+			r:     []string{"memory_pack", "memory_unpack,filter_pads"},
+		},
+		"names with arguments should be split by separator outside of arguments": {
+			names: `first_smaller(memory_pack|memory_unpack)|filter_name(regexp="a|b")`,
+			sep:   '|',
+			r:     []string{"first_smaller(memory_pack|memory_unpack)", `filter_name(regexp="a|b")`},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r := splits(tcase.names, tcase.sep)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package strategies
+
+import (
+	"context"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of atomic presets
+var (
+	atomic64 = atomic{}
+)
+
+// atomic defines strategy implementation
+// that guards 64-bit fields used by sync/atomic functions
+// from misalignment on 32-bit target architectures
+// by moving them to the top of the structure,
+// as the first word in allocated structure
+// can be relied upon to be 64-bit aligned,
+// fields with natural 64-bit alignment are kept in place
+type atomic struct{} // struct size: 0 bytes; struct align: 1 bytes; struct aligned size: 0 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Apply atomic implementation
+func (stg atomic) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// collect all misaligned atomic fields
+	// and the rest of fields separately
+	fields := make([]gopium.Field, 0, len(r.Fields))
+	var atomics []gopium.Field
+	for _, f := range r.Fields {
+		if f.Atomic && f.Size == 8 && f.Align < 8 {
+			atomics = append(atomics, f)
+			continue
+		}
+		fields = append(fields, f)
+	}
+	// in case there are atomic fields
+	// place them before the rest of fields
+	if len(atomics) > 0 {
+		r.Fields = append(atomics, fields...)
+	}
+	return r, ctx.Err()
+}
+
+// Usages atomic implementation
+func (stg atomic) Usages() bool {
+	return true
+}
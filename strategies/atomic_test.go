@@ -0,0 +1,206 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestAtomic(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		atomic atomic
+		ctx    context.Context
+		o      gopium.Struct
+		r      gopium.Struct
+		err    error
+	}{
+		"empty struct should be applied to empty struct": {
+			atomic: atomic64,
+			ctx:    context.Background(),
+		},
+		"non empty struct without atomic fields should be applied to itself": {
+			atomic: atomic64,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"non empty struct with aligned atomic fields should be applied to itself": {
+			atomic: atomic64,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:   "test2",
+						Size:   8,
+						Align:  8,
+						Atomic: true,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:   "test2",
+						Size:   8,
+						Align:  8,
+						Atomic: true,
+					},
+				},
+			},
+		},
+		"non empty struct with misaligned atomic fields should be applied to expected struct": {
+			atomic: atomic64,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:   "test2",
+						Size:   8,
+						Align:  4,
+						Atomic: true,
+					},
+					{
+						Name:  "test3",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:   "test4",
+						Size:   8,
+						Align:  4,
+						Atomic: true,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:   "test2",
+						Size:   8,
+						Align:  4,
+						Atomic: true,
+					},
+					{
+						Name:   "test4",
+						Size:   8,
+						Align:  4,
+						Atomic: true,
+					},
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"non empty struct with misaligned atomic fields should be applied to expected struct on canceled context": {
+			atomic: atomic64,
+			ctx:    cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:   "test2",
+						Size:   8,
+						Align:  4,
+						Atomic: true,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:   "test2",
+						Size:   8,
+						Align:  4,
+						Atomic: true,
+					},
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.atomic.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
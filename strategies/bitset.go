@@ -0,0 +1,140 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of bitset presets
+var (
+	bitsetm = bitset{}
+	bitsett = bitset{}
+)
+
+// bitset defines strategy implementation
+// that collapses unexported bool fields
+// marked by `gopium:"bitset"` tag marker
+// (or all unexported bool fields in case
+// their number reaches provided threshold)
+// into single unsigned flags field
+// with smallest fitting size,
+// flags field keeps collapsed fields names
+// in `gopium:"bitset:a,b,c"` tag marker
+// in bits order, structures with less than
+// two collapsed fields are not changed.
+// note: accessors methods generation
+// and selectors rewriting are done by ast walkers
+type bitset struct {
+	curator   gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	threshold uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_         [8]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Curator erich bitset strategy with curator instance
+func (stg bitset) Curator(curator gopium.Curator) bitset {
+	stg.curator = curator
+	return stg
+}
+
+// Threshold erich bitset strategy with bool fields threshold
+func (stg bitset) Threshold(threshold uint) bitset {
+	stg.threshold = threshold
+	return stg
+}
+
+// Apply bitset implementation
+func (stg bitset) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// collect all bool fields
+	// that could be collapsed
+	var bools, marked []int
+	for i, f := range r.Fields {
+		if f.Type != "bool" || f.Name == "_" || f.Exported || f.Embedded {
+			continue
+		}
+		bools = append(bools, i)
+		if _, ok := collections.Marker(f.Tag, collections.MarkBitset); ok {
+			marked = append(marked, i)
+		}
+	}
+	collapsed := marked
+	if stg.threshold > 0 && uint(len(bools)) >= stg.threshold {
+		collapsed = bools
+	}
+	// single flags field can't
+	// hold more than 64 fields
+	if len(collapsed) > 64 {
+		collapsed = collapsed[:64]
+	}
+	// in case there is nothing to collapse
+	// just return result back
+	if len(collapsed) < 2 {
+		return r, ctx.Err()
+	}
+	names := make([]string, 0, len(collapsed))
+	for _, i := range collapsed {
+		names = append(names, r.Fields[i].Name)
+	}
+	// check that flags field
+	// name is not taken yet
+	fname, _ := collections.Bitset(names[0])
+	for _, f := range r.Fields {
+		if f.Name == fname {
+			return o, fmt.Errorf("field %q in struct %q conflicts with bitset flags field", f.Name, r.Name)
+		}
+	}
+	// replace collapsed fields with flags field
+	// placed instead of first collapsed field,
+	// flags field align can't exceed system align
+	var size int64
+	switch n := len(collapsed); {
+	case n <= 8:
+		size = 1
+	case n <= 16:
+		size = 2
+	case n <= 32:
+		size = 4
+	default:
+		size = 8
+	}
+	align := size
+	if salign := stg.curator.SysAlign(); salign > 0 && salign < align {
+		align = salign
+	}
+	flags := gopium.Field{
+		Name:  fname,
+		Type:  fmt.Sprintf("uint%d", size*8),
+		Size:  size,
+		Align: align,
+		Tag:   fmt.Sprintf(`%s:"%s:%s"`, gopium.NAME, collections.MarkBitset, strings.Join(names, ",")),
+	}
+	fields := make([]gopium.Field, 0, len(r.Fields)-len(collapsed)+1)
+	for i, f := range r.Fields {
+		switch {
+		case i == collapsed[0]:
+			fields = append(fields, flags)
+		case bcontains(collapsed, i):
+			continue
+		default:
+			fields = append(fields, f)
+		}
+	}
+	r.Fields = fields
+	return r, ctx.Err()
+}
+
+// bcontains checks if provided
+// indexes contain provided index
+func bcontains(idxs []int, idx int) bool {
+	for _, i := range idxs {
+		if i == idx {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,442 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestBitset(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		bitset bitset
+		ctx    context.Context
+		o      gopium.Struct
+		r      gopium.Struct
+		err    error
+	}{
+		"empty struct should be applied to empty struct": {
+			bitset: bitsetm.Curator(mocks.Maven{SAlign: 8}),
+			ctx:    context.Background(),
+		},
+		"non empty struct without marked fields should be applied to itself": {
+			bitset: bitsetm.Curator(mocks.Maven{SAlign: 8}),
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"non empty struct with single marked field should be applied to itself": {
+			bitset: bitsetm.Curator(mocks.Maven{SAlign: 8}),
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"non empty struct with marked fields should be applied to flags field": {
+			bitset: bitsetm.Curator(mocks.Maven{SAlign: 8}),
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "i",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "c",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset;memory_pack"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "i",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "flags",
+						Type:  "uint8",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset:a,c"`,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"non empty struct with exported and embedded marked fields should be applied to itself": {
+			bitset: bitsetm.Curator(mocks.Maven{SAlign: 8}),
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:     "A",
+						Type:     "bool",
+						Size:     1,
+						Align:    1,
+						Exported: true,
+						Tag:      `gopium:"bitset"`,
+					},
+					{
+						Name:     "b",
+						Type:     "test.b",
+						Size:     1,
+						Align:    1,
+						Embedded: true,
+						Tag:      `gopium:"bitset"`,
+					},
+					{
+						Name:  "c",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:     "A",
+						Type:     "bool",
+						Size:     1,
+						Align:    1,
+						Exported: true,
+						Tag:      `gopium:"bitset"`,
+					},
+					{
+						Name:     "b",
+						Type:     "test.b",
+						Size:     1,
+						Align:    1,
+						Embedded: true,
+						Tag:      `gopium:"bitset"`,
+					},
+					{
+						Name:  "c",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+				},
+			},
+		},
+		"non empty struct with bool fields below threshold should be applied to itself": {
+			bitset: bitsett.Curator(mocks.Maven{SAlign: 8}).Threshold(3),
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"non empty struct with bool fields reaching threshold should be applied to flags field": {
+			bitset: bitsett.Curator(mocks.Maven{SAlign: 8}).Threshold(2),
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "s",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   8,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "flags",
+						Type:  "uint8",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset:a,b"`,
+					},
+					{
+						Name:  "s",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   8,
+					},
+				},
+			},
+		},
+		"non empty struct with many bool fields should be applied to wider flags field": {
+			bitset: bitsett.Curator(mocks.Maven{SAlign: 4}).Threshold(2),
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: func() []gopium.Field {
+					fields := make([]gopium.Field, 0, 40)
+					for i := 0; i < 40; i++ {
+						fields = append(fields, gopium.Field{Name: fmt.Sprintf("b%d", i), Type: "bool", Size: 1, Align: 1})
+					}
+					return fields
+				}(),
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "flags",
+						Type:  "uint64",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"bitset:b0,b1,b2,b3,b4,b5,b6,b7,b8,b9,b10,b11,b12,b13,b14,b15,b16,b17,b18,b19,b20,b21,b22,b23,b24,b25,b26,b27,b28,b29,b30,b31,b32,b33,b34,b35,b36,b37,b38,b39"`,
+					},
+				},
+			},
+		},
+		"non empty struct with flags field should return expected error": {
+			bitset: bitsetm.Curator(mocks.Maven{SAlign: 8}),
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "flags",
+						Type:  "int",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "flags",
+						Type:  "int",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+				},
+			},
+			err: errors.New(`field "flags" in struct "test" conflicts with bitset flags field`),
+		},
+		"non empty struct with marked fields should be applied to flags field on canceled context": {
+			bitset: bitsetm.Curator(mocks.Maven{SAlign: 8}),
+			ctx:    cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+					{
+						Name:  "b",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "flags",
+						Type:  "uint8",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"bitset:a,b"`,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.bitset.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package strategies
 
 import (
 	"fmt"
+	"go/types"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/1pkg/gopium/gopium"
@@ -13,6 +15,18 @@ const (
 	// pack/unpack mem util
 	Pack   gopium.StrategyName = "memory_pack"
 	Unpack gopium.StrategyName = "memory_unpack"
+	// optimal mem util searches
+	PackOpt  gopium.StrategyName = "memory_pack_optimal"
+	PackOptB gopium.StrategyName = "memory_pack_optimal_budget_%d"
+	// minimal moves mem util
+	PackMin  gopium.StrategyName = "memory_pack_minimal_moves"
+	PackMinB gopium.StrategyName = "memory_pack_minimal_moves_bytes_%d"
+	// nested mem util
+	PackDeep gopium.StrategyName = "memory_pack_deep"
+	// multi arch mem util
+	PackArch gopium.StrategyName = "memory_pack_arch"
+	// gc ptr scan util
+	PScanMin gopium.StrategyName = "pointer_scan_minimize"
 	// explicit sys/type pads
 	PadSys  gopium.StrategyName = "explicit_paddings_system_alignment"
 	PadTnat gopium.StrategyName = "explicit_paddings_type_natural"
@@ -21,6 +35,13 @@ const (
 	FShareL2 gopium.StrategyName = "false_sharing_cpu_l2"
 	FShareL3 gopium.StrategyName = "false_sharing_cpu_l3"
 	FShareB  gopium.StrategyName = "false_sharing_bytes_%d"
+	// false sharing isolations
+	IsolateL1 gopium.StrategyName = "false_sharing_isolate_cpu_l1"
+	IsolateL2 gopium.StrategyName = "false_sharing_isolate_cpu_l2"
+	IsolateL3 gopium.StrategyName = "false_sharing_isolate_cpu_l3"
+	IsolateB  gopium.StrategyName = "false_sharing_isolate_bytes_%d"
+	// atomic fields alignments
+	Atomic64 gopium.StrategyName = "atomic_align_64"
 	// cache line pad roundings
 	CacheL1D gopium.StrategyName = "cache_rounding_cpu_l1_discrete"
 	CacheL2D gopium.StrategyName = "cache_rounding_cpu_l2_discrete"
@@ -30,6 +51,14 @@ const (
 	CacheL2F gopium.StrategyName = "cache_rounding_cpu_l2_full"
 	CacheL3F gopium.StrategyName = "cache_rounding_cpu_l3_full"
 	CacheBF  gopium.StrategyName = "cache_rounding_bytes_%d_full"
+	CacheP   gopium.StrategyName = "cache_rounding"
+	// allocator size class fits
+	SClassFit gopium.StrategyName = "size_class_fit"
+	// cache line straddle guards
+	StraddleL1 gopium.StrategyName = "cache_line_no_straddle_cpu_l1"
+	StraddleL2 gopium.StrategyName = "cache_line_no_straddle_cpu_l2"
+	StraddleL3 gopium.StrategyName = "cache_line_no_straddle_cpu_l3"
+	StraddleB  gopium.StrategyName = "cache_line_no_straddle_bytes_%d"
 	// top, bottom separate pads
 	SepSysT gopium.StrategyName = "separate_padding_system_alignment_top"
 	SepSysB gopium.StrategyName = "separate_padding_system_alignment_bottom"
@@ -41,6 +70,21 @@ const (
 	SepL2B  gopium.StrategyName = "separate_padding_cpu_l2_bottom"
 	SepL3B  gopium.StrategyName = "separate_padding_cpu_l3_bottom"
 	SepBB   gopium.StrategyName = "separate_padding_bytes_%d_bottom"
+	// hot/cold fields splits
+	SplitCold gopium.StrategyName = "hot_cold_split"
+	// hot/warm/cold fields placements
+	HeatL1 gopium.StrategyName = "heat_placement_cpu_l1"
+	HeatL2 gopium.StrategyName = "heat_placement_cpu_l2"
+	HeatL3 gopium.StrategyName = "heat_placement_cpu_l3"
+	HeatB  gopium.StrategyName = "heat_placement_bytes_%d"
+	// fields co-access localities
+	AffinityL1 gopium.StrategyName = "affinity_order"
+	// bool fields bitsets
+	BitsetM gopium.StrategyName = "bool_bitset"
+	BitsetT gopium.StrategyName = "bool_bitset_threshold_%d"
+	// original order records
+	OrderRec  gopium.StrategyName = "record_original_order"
+	OrderRest gopium.StrategyName = "restore_original_order"
 	// tag processors and modifiers
 	ProcTag  gopium.StrategyName = "process_tag_group"
 	AddTagS  gopium.StrategyName = "add_tag_group_soft"
@@ -53,13 +97,33 @@ const (
 	FNoteCom  gopium.StrategyName = "fields_annotate_comment"
 	StNoteDoc gopium.StrategyName = "struct_annotate_doc"
 	StNoteCom gopium.StrategyName = "struct_annotate_comment"
+	FNoteODoc gopium.StrategyName = "fields_annotate_offsets_doc"
+	FNoteOCom gopium.StrategyName = "fields_annotate_offsets_comment"
+	StReport  gopium.StrategyName = "struct_annotate_report"
 	// lexicographical, length, embedded, exported sorts
 	NLexAsc  gopium.StrategyName = "name_lexicographical_ascending"
 	NLexDesc gopium.StrategyName = "name_lexicographical_descending"
 	TLexAsc  gopium.StrategyName = "type_lexicographical_ascending"
 	TLexDesc gopium.StrategyName = "type_lexicographical_descending"
+	NLexP    gopium.StrategyName = "name_lexicographical"
+	TLexP    gopium.StrategyName = "type_lexicographical"
+	SizeAsc  gopium.StrategyName = "size_ascending"
+	SizeDesc gopium.StrategyName = "size_descending"
+	AlgnAsc  gopium.StrategyName = "align_ascending"
+	AlgnDesc gopium.StrategyName = "align_descending"
+	ExpF     gopium.StrategyName = "exported_first"
+	ExpL     gopium.StrategyName = "exported_last"
+	EmbF     gopium.StrategyName = "embedded_first"
+	EmbL     gopium.StrategyName = "embedded_last"
+	// conditional combinators
+	IfSizeGt   gopium.StrategyName = "if_size_gt_%d"
+	IfFieldsGt gopium.StrategyName = "if_fields_gt_%d"
+	IfNameM    gopium.StrategyName = "if_name_match"
+	FSmaller   gopium.StrategyName = "first_smaller"
 	// filters and others
 	FPad   gopium.StrategyName = "filter_pads"
+	FName  gopium.StrategyName = "filter_name"
+	FType  gopium.StrategyName = "filter_type"
 	Ignore gopium.StrategyName = "ignore"
 )
 
@@ -74,6 +138,20 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 	// prepare result strategy pipe
 	p := make(pipe, 0, len(names))
 	for _, name := range names {
+		// build conditional combinators first
+		// as their arguments are strategies names
+		if stg, ok, err := b.combinator(name); ok {
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, stg)
+			continue
+		}
+		// parse strategy name arguments
+		a, err := parsea(name)
+		if err != nil {
+			return nil, err
+		}
 		var stg gopium.Strategy
 		// build strategy by name
 		switch {
@@ -82,6 +160,33 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 			stg = pck
 		case b.marchp(name, Unpack):
 			stg = unpck
+		// optimal mem util searches
+		case b.marchp(name, PackOpt):
+			stg = pckopt
+		case b.marchp(name, PackOptB):
+			var budget uint
+			if err := b.scanp(name, PackOptB, &budget); err != nil {
+				return nil, err
+			}
+			stg = pckoptb.Budget(budget)
+		// minimal moves mem util
+		case b.marchp(name, PackMin):
+			stg = pckmin
+		case b.marchp(name, PackMinB):
+			var bytes uint
+			if err := b.scanp(name, PackMinB, &bytes); err != nil {
+				return nil, err
+			}
+			stg = pckminb.Bytes(bytes)
+		// nested mem util
+		case b.marchp(name, PackDeep):
+			stg = pckdeep
+		// multi arch mem util
+		case a.match(PackArch):
+			stg, err = b.arch(a)
+		// gc ptr scan util
+		case b.marchp(name, PScanMin):
+			stg = pscanmin
 		// explicit sys/type pads
 		case b.marchp(name, PadSys):
 			stg = padsys.Curator(b.Curator)
@@ -100,6 +205,22 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 				return nil, err
 			}
 			stg = fshareb.Bytes(bytes).Curator(b.Curator)
+		// false sharing isolations
+		case b.marchp(name, IsolateL1):
+			stg = isolatel1.Curator(b.Curator)
+		case b.marchp(name, IsolateL2):
+			stg = isolatel2.Curator(b.Curator)
+		case b.marchp(name, IsolateL3):
+			stg = isolatel3.Curator(b.Curator)
+		case b.marchp(name, IsolateB):
+			var bytes uint
+			if err := b.scanp(name, IsolateB, &bytes); err != nil {
+				return nil, err
+			}
+			stg = isolateb.Bytes(bytes).Curator(b.Curator)
+		// atomic fields alignments
+		case b.marchp(name, Atomic64):
+			stg = atomic64
 		// cache line pad roundings
 		case b.marchp(name, CacheL1D):
 			stg = cachel1d.Curator(b.Curator)
@@ -125,6 +246,24 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 				return nil, err
 			}
 			stg = cachebf.Bytes(bytes).Curator(b.Curator)
+		case a.match(CacheP):
+			stg, err = b.cache(a)
+		// allocator size class fits
+		case a.match(SClassFit):
+			stg, err = b.sclass(a)
+		// cache line straddle guards
+		case b.marchp(name, StraddleL1):
+			stg = straddlel1.Curator(b.Curator)
+		case b.marchp(name, StraddleL2):
+			stg = straddlel2.Curator(b.Curator)
+		case b.marchp(name, StraddleL3):
+			stg = straddlel3.Curator(b.Curator)
+		case b.marchp(name, StraddleB):
+			var bytes uint
+			if err := b.scanp(name, StraddleB, &bytes); err != nil {
+				return nil, err
+			}
+			stg = straddleb.Bytes(bytes).Curator(b.Curator)
 		// top, bottom separate pads
 		case b.marchp(name, SepSysT):
 			stg = sepsyst.Curator(b.Curator)
@@ -154,6 +293,39 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 				return nil, err
 			}
 			stg = sepbb.Bytes(bytes).Curator(b.Curator)
+		// hot/cold fields splits
+		case b.marchp(name, SplitCold):
+			stg = splitc.Curator(b.Curator)
+		// hot/warm/cold fields placements
+		case b.marchp(name, HeatL1):
+			stg = heatl1.Curator(b.Curator)
+		case b.marchp(name, HeatL2):
+			stg = heatl2.Curator(b.Curator)
+		case b.marchp(name, HeatL3):
+			stg = heatl3.Curator(b.Curator)
+		case b.marchp(name, HeatB):
+			var bytes uint
+			if err := b.scanp(name, HeatB, &bytes); err != nil {
+				return nil, err
+			}
+			stg = heatb.Bytes(bytes).Curator(b.Curator)
+		// fields co-access localities
+		case b.marchp(name, AffinityL1):
+			stg = affinityl1.Curator(b.Curator)
+		// bool fields bitsets
+		case b.marchp(name, BitsetM):
+			stg = bitsetm.Curator(b.Curator)
+		case b.marchp(name, BitsetT):
+			var threshold uint
+			if err := b.scanp(name, BitsetT, &threshold); err != nil {
+				return nil, err
+			}
+			stg = bitsett.Curator(b.Curator).Threshold(threshold)
+		// original order records
+		case b.marchp(name, OrderRec):
+			stg = ordrec
+		case b.marchp(name, OrderRest):
+			stg = ordrest
 		// tag processors and modifiers
 		case b.marchp(name, ProcTag):
 			stg = ptag.Builder(b)
@@ -176,6 +348,12 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 			stg = stnotedoc
 		case b.marchp(name, StNoteCom):
 			stg = stnotecom
+		case b.marchp(name, FNoteODoc):
+			stg = fnotedoco.Curator(b.Curator)
+		case b.marchp(name, FNoteOCom):
+			stg = fnotecomo.Curator(b.Curator)
+		case b.marchp(name, StReport):
+			stg = streport.Curator(b.Curator)
 		// lexicographical, length, embedded, exported sorts
 		case b.marchp(name, NLexAsc):
 			stg = nlexasc
@@ -185,14 +363,43 @@ func (b Builder) Build(names ...gopium.StrategyName) (gopium.Strategy, error) {
 			stg = tlexasc
 		case b.marchp(name, TLexDesc):
 			stg = tlexdesc
+		case a.match(NLexP):
+			stg, err = b.nlex(a)
+		case a.match(TLexP):
+			stg, err = b.tlex(a)
+		case b.marchp(name, SizeAsc):
+			stg = sizesasc
+		case b.marchp(name, SizeDesc):
+			stg = sizesdesc
+		case b.marchp(name, AlgnAsc):
+			stg = alignsasc
+		case b.marchp(name, AlgnDesc):
+			stg = alignsdesc
+		case b.marchp(name, ExpF):
+			stg = expfirst
+		case b.marchp(name, ExpL):
+			stg = explast
+		case b.marchp(name, EmbF):
+			stg = embfirst
+		case b.marchp(name, EmbL):
+			stg = emblast
 		// filters and others
 		case b.marchp(name, FPad):
 			stg = fpad
+		case a.match(FName):
+			stg, err = b.filter(a, false)
+		case a.match(FType):
+			stg, err = b.filter(a, true)
 		case b.marchp(name, Ignore):
 			stg = ignr
 		default:
 			return nil, fmt.Errorf("strategy %q wasn't found", name)
 		}
+		// in case of any arguments error
+		// just return it back
+		if err != nil {
+			return nil, err
+		}
 		// append strategy to pipe
 		p = append(p, stg)
 	}
@@ -224,3 +431,261 @@ func (b Builder) scanp(name gopium.StrategyName, pattern gopium.StrategyName, va
 	}
 	return nil
 }
+
+// cache builds cache strategy from arguments
+// cache_rounding(line=uint|bytes=uint,mode=discrete|full)
+func (b Builder) cache(a *args) (gopium.Strategy, error) {
+	line, err := a.uint("line", 0)
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := a.uint("bytes", 0)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := a.enum("mode", "discrete", "discrete", "full")
+	if err != nil {
+		return nil, err
+	}
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+	// exactly one of line or bytes
+	// arguments should be provided
+	if (line == 0) == (bytes == 0) {
+		return nil, a.errorf(len(a.name), "exactly one of arguments %q or %q is required", "line", "bytes")
+	}
+	return cache{line: line, bytes: bytes, div: mode == "discrete"}.Curator(b.Curator), nil
+}
+
+// arch builds arch strategy from arguments
+// memory_pack_arch(targets=[string,...],mode=sum|max,weights=[uint,...])
+func (b Builder) arch(a *args) (gopium.Strategy, error) {
+	targets, err := a.list("targets")
+	if err != nil {
+		return nil, err
+	}
+	mode, err := a.enum("mode", "sum", "sum", "max")
+	if err != nil {
+		return nil, err
+	}
+	ws, err := a.list("weights")
+	if err != nil {
+		return nil, err
+	}
+	if err := a.check("targets"); err != nil {
+		return nil, err
+	}
+	ar, _ := a.get("targets")
+	if len(targets) == 0 {
+		return nil, a.errorf(ar.pos, "argument %q expected at least one target", "targets")
+	}
+	// targets are either arch or compiler/arch
+	// with gc compiler used by default
+	for i, target := range targets {
+		compiler, arch := "gc", target
+		if j := strings.IndexByte(target, '/'); j >= 0 {
+			compiler, arch = target[:j], target[j+1:]
+		}
+		if types.SizesFor(compiler, arch) == nil {
+			return nil, a.errorf(ar.pos, "argument %q unsuported compiler %q arch %q combination", "targets", compiler, arch)
+		}
+		targets[i] = fmt.Sprintf("%s/%s", compiler, arch)
+	}
+	weights := make([]uint, 0, len(ws))
+	for _, w := range ws {
+		weight, err := strconv.ParseUint(w, 10, 0)
+		if err != nil {
+			wr, _ := a.get("weights")
+			return nil, a.errorf(wr.pos, "argument %q expected list of uint", "weights")
+		}
+		weights = append(weights, uint(weight))
+	}
+	// weights should match targets
+	// if they are provided
+	if ws != nil && len(weights) != len(targets) {
+		wr, _ := a.get("weights")
+		return nil, a.errorf(wr.pos, "argument %q expected %d weights", "weights", len(targets))
+	}
+	stg := pckarchsum
+	if mode == "max" {
+		stg = pckarchmax
+	}
+	stg = stg.Targets(targets...)
+	if ws != nil {
+		stg = stg.Weights(weights...)
+	}
+	return stg, nil
+}
+
+// sclass builds sclass strategy from arguments
+// size_class_fit(mode=fit|pad)
+func (b Builder) sclass(a *args) (gopium.Strategy, error) {
+	mode, err := a.enum("mode", "fit", "fit", "pad")
+	if err != nil {
+		return nil, err
+	}
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+	if mode == "pad" {
+		return sclasspad, nil
+	}
+	return sclassfit, nil
+}
+
+// nlex builds nlex strategy from arguments
+// name_lexicographical(order=ascending|descending)
+func (b Builder) nlex(a *args) (gopium.Strategy, error) {
+	order, err := a.enum("order", "ascending", "ascending", "descending")
+	if err != nil {
+		return nil, err
+	}
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+	return nlex{asc: order == "ascending"}, nil
+}
+
+// tlex builds tlex strategy from arguments
+// type_lexicographical(order=ascending|descending)
+func (b Builder) tlex(a *args) (gopium.Strategy, error) {
+	order, err := a.enum("order", "ascending", "ascending", "descending")
+	if err != nil {
+		return nil, err
+	}
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+	return tlex{asc: order == "ascending"}, nil
+}
+
+// filter builds filter strategy from arguments
+// filter_name(regexp=string|names=[string,...])
+// filter_type(regexp=string|names=[string,...])
+func (b Builder) filter(a *args, types bool) (gopium.Strategy, error) {
+	regex, err := a.regexp("regexp")
+	if err != nil {
+		return nil, err
+	}
+	names, err := a.list("names")
+	if err != nil {
+		return nil, err
+	}
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+	// exactly one of regexp or names
+	// arguments should be provided
+	if (regex == nil) == (names == nil) {
+		return nil, a.errorf(len(a.name), "exactly one of arguments %q or %q is required", "regexp", "names")
+	}
+	// names list is converted
+	// to exact match regexp
+	if names != nil {
+		for i, name := range names {
+			names[i] = regexp.QuoteMeta(name)
+		}
+		regex = regexp.MustCompile(fmt.Sprintf("^(%s)$", strings.Join(names, "|")))
+	}
+	if types {
+		return filter{tregex: regex}, nil
+	}
+	return filter{nregex: regex}, nil
+}
+
+// combinator builds conditional combinator strategy
+// if strategy name is combinator name
+// if_size_gt_%d(name,...)
+// if_fields_gt_%d(name,...)
+// if_name_match(regexp,name,...)
+// first_smaller(name,...|name,...)
+func (b Builder) combinator(name gopium.StrategyName) (gopium.Strategy, bool, error) {
+	// split combinator name
+	// to its base and body
+	s := string(name)
+	i := strings.IndexByte(s, '(')
+	if i < 0 {
+		return nil, false, nil
+	}
+	base, body := gopium.StrategyName(s[:i]), s[i+1:]
+	switch {
+	case b.marchp(base, IfSizeGt), b.marchp(base, IfFieldsGt), base == IfNameM, base == FSmaller:
+	default:
+		return nil, false, nil
+	}
+	a := &args{name: name}
+	if !strings.HasSuffix(body, ")") {
+		return nil, true, a.errorf(len(s), "expected closing parenthesis")
+	}
+	body = body[:len(body)-1]
+	if strings.TrimSpace(body) == "" {
+		return nil, true, a.errorf(i+1, "expected strategies")
+	}
+	// build provided combinator
+	switch {
+	case b.marchp(base, IfSizeGt), b.marchp(base, IfFieldsGt):
+		cond, pattern := ifsize, IfSizeGt
+		if b.marchp(base, IfFieldsGt) {
+			cond, pattern = iffields, IfFieldsGt
+		}
+		var limit uint
+		if err := b.scanp(base, pattern, &limit); err != nil {
+			return nil, true, err
+		}
+		stg, err := b.Build(b.names(body)...)
+		if err != nil {
+			return nil, true, err
+		}
+		return cond.Limit(limit).Strategy(stg), true, nil
+	case base == IfNameM:
+		names := b.names(body)
+		// regex is either go double quoted
+		// string or bare value
+		expr := string(names[0])
+		if strings.HasPrefix(expr, `"`) {
+			unq, err := strconv.Unquote(expr)
+			if err != nil {
+				return nil, true, a.errorf(i+1, "invalid quoted value %v", err)
+			}
+			expr = unq
+		}
+		regex, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, true, a.errorf(i+1, "expected regexp %v", err)
+		}
+		if len(names) < 2 {
+			return nil, true, a.errorf(len(s), "expected strategies")
+		}
+		stg, err := b.Build(names[1:]...)
+		if err != nil {
+			return nil, true, err
+		}
+		return ifname.Regex(regex).Strategy(stg), true, nil
+	default:
+		alts := splits(body, '|')
+		if len(alts) < 2 {
+			return nil, true, a.errorf(i+1, "expected at least two alternatives")
+		}
+		stgs := make(smaller, 0, len(alts))
+		for _, alt := range alts {
+			stg, err := b.Build(b.names(alt)...)
+			if err != nil {
+				return nil, true, err
+			}
+			stgs = append(stgs, stg)
+		}
+		return stgs, true, nil
+	}
+}
+
+// names splits combinator body
+// to trimmed strategies names
+func (b Builder) names(body string) []gopium.StrategyName {
+	parts := splita(body)
+	names := make([]gopium.StrategyName, 0, len(parts))
+	for _, part := range parts {
+		names = append(names, gopium.StrategyName(strings.TrimSpace(part)))
+	}
+	return names
+}
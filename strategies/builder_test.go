@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/1pkg/gopium/gopium"
@@ -27,6 +28,42 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{Unpack},
 			stg:   pipe([]gopium.Strategy{unpck}),
 		},
+		// optimal mem util searches
+		"`memory_pack_optimal` name should return expected strategy": {
+			names: []gopium.StrategyName{PackOpt},
+			stg:   pipe([]gopium.Strategy{pckopt}),
+		},
+		"`memory_pack_optimal_budget_1024` name should return expected strategy": {
+			names: []gopium.StrategyName{"memory_pack_optimal_budget_1024"},
+			stg:   pipe([]gopium.Strategy{pckoptb.Budget(1024)}),
+		},
+		"`memory_pack_optimal_budget_-10` name should return expected error": {
+			names: []gopium.StrategyName{"memory_pack_optimal_budget_-10"},
+			err:   errors.New(`pattern "memory_pack_optimal_budget_%d" can't be scanned for strategy "memory_pack_optimal_budget_-10" expected integer`),
+		},
+		// minimal moves mem util
+		"`memory_pack_minimal_moves` name should return expected strategy": {
+			names: []gopium.StrategyName{PackMin},
+			stg:   pipe([]gopium.Strategy{pckmin}),
+		},
+		"`memory_pack_minimal_moves_bytes_32` name should return expected strategy": {
+			names: []gopium.StrategyName{"memory_pack_minimal_moves_bytes_32"},
+			stg:   pipe([]gopium.Strategy{pckminb.Bytes(32)}),
+		},
+		"`memory_pack_minimal_moves_bytes_-10` name should return expected error": {
+			names: []gopium.StrategyName{"memory_pack_minimal_moves_bytes_-10"},
+			err:   errors.New(`pattern "memory_pack_minimal_moves_bytes_%d" can't be scanned for strategy "memory_pack_minimal_moves_bytes_-10" expected integer`),
+		},
+		// nested mem util
+		"`memory_pack_deep` name should return expected strategy": {
+			names: []gopium.StrategyName{PackDeep},
+			stg:   pipe([]gopium.Strategy{pckdeep}),
+		},
+		// gc ptr scan util
+		"`pointer_scan_minimize` name should return expected strategy": {
+			names: []gopium.StrategyName{PScanMin},
+			stg:   pipe([]gopium.Strategy{pscanmin}),
+		},
 		// explicit sys/type pads
 		"`explicit_paddings_system_alignment` name should return expected strategy": {
 			names: []gopium.StrategyName{PadSys},
@@ -61,6 +98,32 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{"false_sharing_bytes_err"},
 			err:   errors.New(`pattern "false_sharing_bytes_%d" can't be scanned for strategy "false_sharing_bytes_err" expected integer`),
 		},
+		// false sharing isolations
+		"`false_sharing_isolate_cpu_l1` name should return expected strategy": {
+			names: []gopium.StrategyName{IsolateL1},
+			stg:   pipe([]gopium.Strategy{isolatel1.Curator(b.Curator)}),
+		},
+		"`false_sharing_isolate_cpu_l2` name should return expected strategy": {
+			names: []gopium.StrategyName{IsolateL2},
+			stg:   pipe([]gopium.Strategy{isolatel2.Curator(b.Curator)}),
+		},
+		"`false_sharing_isolate_cpu_l3` name should return expected strategy": {
+			names: []gopium.StrategyName{IsolateL3},
+			stg:   pipe([]gopium.Strategy{isolatel3.Curator(b.Curator)}),
+		},
+		"`false_sharing_isolate_bytes_12` name should return expected strategy": {
+			names: []gopium.StrategyName{"false_sharing_isolate_bytes_12"},
+			stg:   pipe([]gopium.Strategy{isolateb.Bytes(12).Curator(b.Curator)}),
+		},
+		"`false_sharing_isolate_bytes_err` name should return expected error": {
+			names: []gopium.StrategyName{"false_sharing_isolate_bytes_err"},
+			err:   errors.New(`pattern "false_sharing_isolate_bytes_%d" can't be scanned for strategy "false_sharing_isolate_bytes_err" expected integer`),
+		},
+		// atomic fields alignments
+		"`atomic_align_64` name should return expected strategy": {
+			names: []gopium.StrategyName{Atomic64},
+			stg:   pipe([]gopium.Strategy{atomic64}),
+		},
 		// cache line pad roundings
 		"`cache_rounding_cpu_l1_discrete` name should return expected strategy": {
 			names: []gopium.StrategyName{CacheL1D},
@@ -102,6 +165,64 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{"cache_rounding_bytes_err_full"},
 			err:   errors.New(`pattern "cache_rounding_bytes_%d_full" can't be scanned for strategy "cache_rounding_bytes_err_full" expected integer`),
 		},
+		"`cache_rounding(line=2,mode=full)` name should return expected strategy": {
+			names: []gopium.StrategyName{"cache_rounding(line=2,mode=full)"},
+			stg:   pipe([]gopium.Strategy{cachel2f.Curator(b.Curator)}),
+		},
+		"`cache_rounding(bytes=128)` name should return expected strategy": {
+			names: []gopium.StrategyName{"cache_rounding(bytes=128)"},
+			stg:   pipe([]gopium.Strategy{cachebd.Bytes(128).Curator(b.Curator)}),
+		},
+		"`cache_rounding(bytes=x)` name should return expected error": {
+			names: []gopium.StrategyName{"cache_rounding(bytes=x)"},
+			err:   errors.New(`strategy "cache_rounding(bytes=x)" at 21 argument "bytes" expected uint`),
+		},
+		"`cache_rounding(bytes=128,mode=half)` name should return expected error": {
+			names: []gopium.StrategyName{"cache_rounding(bytes=128,mode=half)"},
+			err:   errors.New(`strategy "cache_rounding(bytes=128,mode=half)" at 30 argument "mode" expected one of discrete|full`),
+		},
+		"`cache_rounding(line=1,bytes=128)` name should return expected error": {
+			names: []gopium.StrategyName{"cache_rounding(line=1,bytes=128)"},
+			err:   errors.New(`strategy "cache_rounding(line=1,bytes=128)" at 32 exactly one of arguments "line" or "bytes" is required`),
+		},
+		"`cache_rounding(size=128)` name should return expected error": {
+			names: []gopium.StrategyName{"cache_rounding(size=128)"},
+			err:   errors.New(`strategy "cache_rounding(size=128)" at 20 argument "size" is unknown`),
+		},
+		// allocator size class fits
+		"`size_class_fit` name should return expected strategy": {
+			names: []gopium.StrategyName{SClassFit},
+			stg:   pipe([]gopium.Strategy{sclassfit}),
+		},
+		"`size_class_fit(mode=pad)` name should return expected strategy": {
+			names: []gopium.StrategyName{"size_class_fit(mode=pad)"},
+			stg:   pipe([]gopium.Strategy{sclasspad}),
+		},
+		"`size_class_fit(mode=round)` name should return expected error": {
+			names: []gopium.StrategyName{"size_class_fit(mode=round)"},
+			err:   errors.New(`strategy "size_class_fit(mode=round)" at 20 argument "mode" expected one of fit|pad`),
+		},
+		// cache line straddle guards
+		"`cache_line_no_straddle_cpu_l1` name should return expected strategy": {
+			names: []gopium.StrategyName{StraddleL1},
+			stg:   pipe([]gopium.Strategy{straddlel1.Curator(b.Curator)}),
+		},
+		"`cache_line_no_straddle_cpu_l2` name should return expected strategy": {
+			names: []gopium.StrategyName{StraddleL2},
+			stg:   pipe([]gopium.Strategy{straddlel2.Curator(b.Curator)}),
+		},
+		"`cache_line_no_straddle_cpu_l3` name should return expected strategy": {
+			names: []gopium.StrategyName{StraddleL3},
+			stg:   pipe([]gopium.Strategy{straddlel3.Curator(b.Curator)}),
+		},
+		"`cache_line_no_straddle_bytes_32` name should return expected strategy": {
+			names: []gopium.StrategyName{"cache_line_no_straddle_bytes_32"},
+			stg:   pipe([]gopium.Strategy{straddleb.Bytes(32).Curator(b.Curator)}),
+		},
+		"`cache_line_no_straddle_bytes_err` name should return expected error": {
+			names: []gopium.StrategyName{"cache_line_no_straddle_bytes_err"},
+			err:   errors.New(`pattern "cache_line_no_straddle_bytes_%d" can't be scanned for strategy "cache_line_no_straddle_bytes_err" expected integer`),
+		},
 		// top, bottom separate pads
 		"`separate_padding_system_alignment_top` name should return expected strategy": {
 			names: []gopium.StrategyName{SepSysT},
@@ -151,6 +272,59 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{"separate_padding_bytes_err_bottom"},
 			err:   errors.New(`pattern "separate_padding_bytes_%d_bottom" can't be scanned for strategy "separate_padding_bytes_err_bottom" expected integer`),
 		},
+		// hot/cold fields splits
+		"`hot_cold_split` name should return expected strategy": {
+			names: []gopium.StrategyName{SplitCold},
+			stg:   pipe([]gopium.Strategy{splitc.Curator(b.Curator)}),
+		},
+		// hot/warm/cold fields placements
+		"`heat_placement_cpu_l1` name should return expected strategy": {
+			names: []gopium.StrategyName{HeatL1},
+			stg:   pipe([]gopium.Strategy{heatl1.Curator(b.Curator)}),
+		},
+		"`heat_placement_cpu_l2` name should return expected strategy": {
+			names: []gopium.StrategyName{HeatL2},
+			stg:   pipe([]gopium.Strategy{heatl2.Curator(b.Curator)}),
+		},
+		"`heat_placement_cpu_l3` name should return expected strategy": {
+			names: []gopium.StrategyName{HeatL3},
+			stg:   pipe([]gopium.Strategy{heatl3.Curator(b.Curator)}),
+		},
+		"`heat_placement_bytes_32` name should return expected strategy": {
+			names: []gopium.StrategyName{"heat_placement_bytes_32"},
+			stg:   pipe([]gopium.Strategy{heatb.Bytes(32).Curator(b.Curator)}),
+		},
+		"`heat_placement_bytes_err` name should return expected error": {
+			names: []gopium.StrategyName{"heat_placement_bytes_err"},
+			err:   errors.New(`pattern "heat_placement_bytes_%d" can't be scanned for strategy "heat_placement_bytes_err" expected integer`),
+		},
+		// fields co-access localities
+		"`affinity_order` name should return expected strategy": {
+			names: []gopium.StrategyName{AffinityL1},
+			stg:   pipe([]gopium.Strategy{affinityl1.Curator(b.Curator)}),
+		},
+		// bool fields bitsets
+		"`bool_bitset` name should return expected strategy": {
+			names: []gopium.StrategyName{BitsetM},
+			stg:   pipe([]gopium.Strategy{bitsetm.Curator(b.Curator)}),
+		},
+		"`bool_bitset_threshold_4` name should return expected strategy": {
+			names: []gopium.StrategyName{"bool_bitset_threshold_4"},
+			stg:   pipe([]gopium.Strategy{bitsett.Curator(b.Curator).Threshold(4)}),
+		},
+		"`bool_bitset_threshold_-4` name should return expected error": {
+			names: []gopium.StrategyName{"bool_bitset_threshold_-4"},
+			err:   errors.New(`pattern "bool_bitset_threshold_%d" can't be scanned for strategy "bool_bitset_threshold_-4" expected integer`),
+		},
+		// original order records
+		"`record_original_order` name should return expected strategy": {
+			names: []gopium.StrategyName{OrderRec},
+			stg:   pipe([]gopium.Strategy{ordrec}),
+		},
+		"`restore_original_order` name should return expected strategy": {
+			names: []gopium.StrategyName{OrderRest},
+			stg:   pipe([]gopium.Strategy{ordrest}),
+		},
 		// tag processors and modifiers
 		"`process_tag_group` name should return expected strategy": {
 			names: []gopium.StrategyName{ProcTag},
@@ -193,6 +367,18 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{StNoteCom},
 			stg:   pipe([]gopium.Strategy{stnotecom}),
 		},
+		"`fields_annotate_offsets_doc` name should return expected strategy": {
+			names: []gopium.StrategyName{FNoteODoc},
+			stg:   pipe([]gopium.Strategy{fnotedoco.Curator(b.Curator)}),
+		},
+		"`fields_annotate_offsets_comment` name should return expected strategy": {
+			names: []gopium.StrategyName{FNoteOCom},
+			stg:   pipe([]gopium.Strategy{fnotecomo.Curator(b.Curator)}),
+		},
+		"`struct_annotate_report` name should return expected strategy": {
+			names: []gopium.StrategyName{StReport},
+			stg:   pipe([]gopium.Strategy{streport.Curator(b.Curator)}),
+		},
 		// lexicographical, length, embedded, exported sorts
 		"`name_lexicographical_ascending` name should return expected strategy": {
 			names: []gopium.StrategyName{NLexAsc},
@@ -210,11 +396,191 @@ func TestBuilder(t *testing.T) {
 			names: []gopium.StrategyName{TLexDesc},
 			stg:   pipe([]gopium.Strategy{tlexdesc}),
 		},
+		"`name_lexicographical(order=descending)` name should return expected strategy": {
+			names: []gopium.StrategyName{"name_lexicographical(order=descending)"},
+			stg:   pipe([]gopium.Strategy{nlexdesc}),
+		},
+		"`type_lexicographical` name should return expected strategy": {
+			names: []gopium.StrategyName{TLexP},
+			stg:   pipe([]gopium.Strategy{tlexasc}),
+		},
+		"`type_lexicographical(order=[ascending])` name should return expected error": {
+			names: []gopium.StrategyName{"type_lexicographical(order=[ascending])"},
+			err:   errors.New(`strategy "type_lexicographical(order=[ascending])" at 27 argument "order" expected one of ascending|descending`),
+		},
+		"`size_ascending` name should return expected strategy": {
+			names: []gopium.StrategyName{SizeAsc},
+			stg:   pipe([]gopium.Strategy{sizesasc}),
+		},
+		"`size_descending` name should return expected strategy": {
+			names: []gopium.StrategyName{SizeDesc},
+			stg:   pipe([]gopium.Strategy{sizesdesc}),
+		},
+		"`align_ascending` name should return expected strategy": {
+			names: []gopium.StrategyName{AlgnAsc},
+			stg:   pipe([]gopium.Strategy{alignsasc}),
+		},
+		"`align_descending` name should return expected strategy": {
+			names: []gopium.StrategyName{AlgnDesc},
+			stg:   pipe([]gopium.Strategy{alignsdesc}),
+		},
+		"`exported_first` name should return expected strategy": {
+			names: []gopium.StrategyName{ExpF},
+			stg:   pipe([]gopium.Strategy{expfirst}),
+		},
+		"`exported_last` name should return expected strategy": {
+			names: []gopium.StrategyName{ExpL},
+			stg:   pipe([]gopium.Strategy{explast}),
+		},
+		"`embedded_first` name should return expected strategy": {
+			names: []gopium.StrategyName{EmbF},
+			stg:   pipe([]gopium.Strategy{embfirst}),
+		},
+		"`embedded_last` name should return expected strategy": {
+			names: []gopium.StrategyName{EmbL},
+			stg:   pipe([]gopium.Strategy{emblast}),
+		},
+		// multi arch mem util
+		"`memory_pack_arch(targets=[amd64,gccgo/arm])` name should return expected strategy": {
+			names: []gopium.StrategyName{"memory_pack_arch(targets=[amd64,gccgo/arm])"},
+			stg: pipe([]gopium.Strategy{
+				pckarchsum.Targets("gc/amd64", "gccgo/arm"),
+			}),
+		},
+		"`memory_pack_arch(targets=[amd64,386],mode=sum,weights=[2,1])` name should return expected strategy": {
+			names: []gopium.StrategyName{"memory_pack_arch(targets=[amd64,386],mode=sum,weights=[2,1])"},
+			stg: pipe([]gopium.Strategy{
+				pckarchsum.Targets("gc/amd64", "gc/386").Weights(2, 1),
+			}),
+		},
+		"`memory_pack_arch(targets=386,mode=max)` name should return expected strategy": {
+			names: []gopium.StrategyName{"memory_pack_arch(targets=386,mode=max)"},
+			stg: pipe([]gopium.Strategy{
+				pckarchmax.Targets("gc/386"),
+			}),
+		},
+		"`memory_pack_arch` name should return expected error": {
+			names: []gopium.StrategyName{"memory_pack_arch"},
+			err:   errors.New(`strategy "memory_pack_arch" at 16 argument "targets" is required`),
+		},
+		"`memory_pack_arch(targets=[])` name should return expected error": {
+			names: []gopium.StrategyName{"memory_pack_arch(targets=[])"},
+			err:   errors.New(`strategy "memory_pack_arch(targets=[])" at 25 argument "targets" expected at least one target`),
+		},
+		"`memory_pack_arch(targets=[amd64,test])` name should return expected error": {
+			names: []gopium.StrategyName{"memory_pack_arch(targets=[amd64,test])"},
+			err:   errors.New(`strategy "memory_pack_arch(targets=[amd64,test])" at 25 argument "targets" unsuported compiler "gc" arch "test" combination`),
+		},
+		"`memory_pack_arch(targets=[amd64,386],weights=[1])` name should return expected error": {
+			names: []gopium.StrategyName{"memory_pack_arch(targets=[amd64,386],weights=[1])"},
+			err:   errors.New(`strategy "memory_pack_arch(targets=[amd64,386],weights=[1])" at 45 argument "weights" expected 2 weights`),
+		},
+		"`memory_pack_arch(targets=[amd64],weights=[-1])` name should return expected error": {
+			names: []gopium.StrategyName{"memory_pack_arch(targets=[amd64],weights=[-1])"},
+			err:   errors.New(`strategy "memory_pack_arch(targets=[amd64],weights=[-1])" at 41 argument "weights" expected list of uint`),
+		},
+		"`memory_pack_arch(targets=[amd64],mode=min)` name should return expected error": {
+			names: []gopium.StrategyName{"memory_pack_arch(targets=[amd64],mode=min)"},
+			err:   errors.New(`strategy "memory_pack_arch(targets=[amd64],mode=min)" at 38 argument "mode" expected one of sum|max`),
+		},
+		// conditional combinators
+		"`if_size_gt_64(memory_pack,cache_rounding_cpu_l1_discrete)` name should return expected strategy": {
+			names: []gopium.StrategyName{"if_size_gt_64(memory_pack,cache_rounding_cpu_l1_discrete)"},
+			stg: pipe([]gopium.Strategy{
+				ifsize.Limit(64).Strategy(pipe([]gopium.Strategy{pck, cachel1d.Curator(b.Curator)})),
+			}),
+		},
+		"`if_size_gt_-64(memory_pack)` name should return expected error": {
+			names: []gopium.StrategyName{"if_size_gt_-64(memory_pack)"},
+			err:   errors.New(`pattern "if_size_gt_%d" can't be scanned for strategy "if_size_gt_-64" expected integer`),
+		},
+		"`if_size_gt_64(memory_pack` name should return expected error": {
+			names: []gopium.StrategyName{"if_size_gt_64(memory_pack"},
+			err:   errors.New(`strategy "if_size_gt_64(memory_pack" at 25 expected closing parenthesis`),
+		},
+		"`if_size_gt_64()` name should return expected error": {
+			names: []gopium.StrategyName{"if_size_gt_64()"},
+			err:   errors.New(`strategy "if_size_gt_64()" at 14 expected strategies`),
+		},
+		"`if_fields_gt_4(if_size_gt_64(memory_pack),filter_pads)` name should return expected strategy": {
+			names: []gopium.StrategyName{"if_fields_gt_4(if_size_gt_64(memory_pack),filter_pads)"},
+			stg: pipe([]gopium.Strategy{
+				iffields.Limit(4).Strategy(pipe([]gopium.Strategy{
+					ifsize.Limit(64).Strategy(pipe([]gopium.Strategy{pck})),
+					fpad,
+				})),
+			}),
+		},
+		"`if_fields_gt_4(test)` name should return expected error": {
+			names: []gopium.StrategyName{"if_fields_gt_4(test)"},
+			err:   errors.New(`strategy "test" wasn't found`),
+		},
+		"`if_name_match(\"^A|B$\",memory_pack)` name should return expected strategy": {
+			names: []gopium.StrategyName{`if_name_match("^A|B$",memory_pack)`},
+			stg: pipe([]gopium.Strategy{
+				ifname.Regex(regexp.MustCompile(`^A|B$`)).Strategy(pipe([]gopium.Strategy{pck})),
+			}),
+		},
+		"`if_name_match(^A$, memory_pack)` name should return expected strategy": {
+			names: []gopium.StrategyName{`if_name_match(^A$, memory_pack)`},
+			stg: pipe([]gopium.Strategy{
+				ifname.Regex(regexp.MustCompile(`^A$`)).Strategy(pipe([]gopium.Strategy{pck})),
+			}),
+		},
+		"`if_name_match(^A$)` name should return expected error": {
+			names: []gopium.StrategyName{`if_name_match(^A$)`},
+			err:   errors.New(`strategy "if_name_match(^A$)" at 18 expected strategies`),
+		},
+		"`if_name_match([,memory_pack)` name should return expected error": {
+			names: []gopium.StrategyName{`if_name_match([,memory_pack)`},
+			err:   errors.New("strategy \"if_name_match([,memory_pack)\" at 14 expected regexp error parsing regexp: missing closing ]: `[,memory_pack`"),
+		},
+		"`if_name_match(\"^A,memory_pack)` name should return expected error": {
+			names: []gopium.StrategyName{`if_name_match("^A,memory_pack)`},
+			err:   errors.New(`strategy "if_name_match(\"^A,memory_pack)" at 14 invalid quoted value invalid syntax`),
+		},
+		"`first_smaller(memory_pack|memory_unpack,filter_pads)` name should return expected strategy": {
+			names: []gopium.StrategyName{"first_smaller(memory_pack|memory_unpack,filter_pads)"},
+			stg: pipe([]gopium.Strategy{
+				smaller{
+					pipe([]gopium.Strategy{pck}),
+					pipe([]gopium.Strategy{unpck, fpad}),
+				},
+			}),
+		},
+		"`first_smaller(memory_pack)` name should return expected error": {
+			names: []gopium.StrategyName{"first_smaller(memory_pack)"},
+			err:   errors.New(`strategy "first_smaller(memory_pack)" at 14 expected at least two alternatives`),
+		},
+		"`first_smaller(memory_pack|test)` name should return expected error": {
+			names: []gopium.StrategyName{"first_smaller(memory_pack|test)"},
+			err:   errors.New(`strategy "test" wasn't found`),
+		},
 		// filters and others
 		"`filter_pads` name should return expected strategy": {
 			names: []gopium.StrategyName{FPad},
 			stg:   pipe([]gopium.Strategy{fpad}),
 		},
+		"`filter_name(regexp=\"^_$\")` name should return expected strategy": {
+			names: []gopium.StrategyName{`filter_name(regexp="^_$")`},
+			stg:   pipe([]gopium.Strategy{fpad}),
+		},
+		"`filter_type(names=[sync.Mutex,int])` name should return expected strategy": {
+			names: []gopium.StrategyName{"filter_type(names=[sync.Mutex,int])"},
+			stg:   pipe([]gopium.Strategy{filter{tregex: regexp.MustCompile(`^(sync\.Mutex|int)$`)}}),
+		},
+		"`filter_type(regexp=\"[\")` name should return expected error": {
+			names: []gopium.StrategyName{`filter_type(regexp="[")`},
+			err:   errors.New(`strategy "filter_type(regexp=\"[\")" at 19 argument "regexp" expected regexp error parsing regexp: missing closing ]: ` + "`[`"),
+		},
+		"`filter_type` name should return expected error": {
+			names: []gopium.StrategyName{FType},
+			err:   errors.New(`strategy "filter_type" at 11 exactly one of arguments "regexp" or "names" is required`),
+		},
+		"`filter_type(regexp` name should return expected error": {
+			names: []gopium.StrategyName{"filter_type(regexp"},
+			err:   errors.New(`strategy "filter_type(regexp" at 18 expected closing parenthesis`),
+		},
 		"`ignore` name should return expected strategy": {
 			names: []gopium.StrategyName{Ignore},
 			stg:   pipe([]gopium.Strategy{ignr}),
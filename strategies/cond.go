@@ -0,0 +1,146 @@
+package strategies
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of cond presets
+var (
+	ifsize   = cond{kind: "size"}
+	iffields = cond{kind: "fields"}
+	ifname   = cond{kind: "name"}
+)
+
+// cond defines strategy implementation
+// that applies inner strategy only to structures
+// which satisfy condition of provided kind:
+// - size: structure aligned size is greater than limit
+// - fields: structure number of fields is greater than limit
+// - name: structure name matches regex
+// otherwise structure is kept as is
+type cond struct {
+	stg   gopium.Strategy `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	regex *regexp.Regexp  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	kind  string          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	limit uint            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [16]byte        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
+
+// Strategy erich cond strategy with inner strategy
+func (stg cond) Strategy(inner gopium.Strategy) cond {
+	stg.stg = inner
+	return stg
+}
+
+// Regex erich cond strategy with regex
+func (stg cond) Regex(regex *regexp.Regexp) cond {
+	stg.regex = regex
+	return stg
+}
+
+// Limit erich cond strategy with limit
+func (stg cond) Limit(limit uint) cond {
+	stg.limit = limit
+	return stg
+}
+
+// Apply cond implementation
+func (stg cond) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// check structure condition
+	// accordingly to its current layout
+	var ok bool
+	switch stg.kind {
+	case "size":
+		size, _, _ := collections.SizeAlignPtr(r)
+		ok = size > int64(stg.limit)
+	case "fields":
+		ok = uint(len(r.Fields)) > stg.limit
+	case "name":
+		ok = stg.regex != nil && stg.regex.MatchString(r.Name)
+	}
+	// in case condition isn't satisfied
+	// just return result back
+	if !ok || stg.stg == nil {
+		return r, ctx.Err()
+	}
+	return stg.stg.Apply(ctx, r)
+}
+
+// Nested cond implementation
+func (stg cond) Nested() bool {
+	nstg, ok := stg.stg.(gopium.NestedStrategy)
+	return ok && nstg.Nested()
+}
+
+// Archs cond implementation
+func (stg cond) Archs() []string {
+	if astg, ok := stg.stg.(gopium.ArchStrategy); ok {
+		return astg.Archs()
+	}
+	return nil
+}
+
+// Usages cond implementation
+func (stg cond) Usages() bool {
+	ustg, ok := stg.stg.(gopium.UsageStrategy)
+	return ok && ustg.Usages()
+}
+
+// smaller defines strategy implementation
+// that applies all inner strategies
+// to original structure independently
+// and picks result of the first strategy
+// with the smallest structure aligned size
+type smaller []gopium.Strategy
+
+// Apply smaller implementation
+func (stgs smaller) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// go through all inner strategies
+	// and pick the smallest result
+	bsize := int64(-1)
+	for _, stg := range stgs {
+		// manage context actions
+		// in case of cancelation
+		// stop execution
+		select {
+		case <-ctx.Done():
+			return o, ctx.Err()
+		default:
+		}
+		tmp, err := stg.Apply(ctx, collections.CopyStruct(o))
+		// in case of any error
+		// return immediately
+		if err != nil {
+			return o, err
+		}
+		// keep only strictly smaller results
+		// so the first one wins on ties
+		if size, _, _ := collections.SizeAlignPtr(tmp); bsize < 0 || size < bsize {
+			r, bsize = tmp, size
+		}
+	}
+	return r, ctx.Err()
+}
+
+// Nested smaller implementation
+func (stgs smaller) Nested() bool {
+	return pipe(stgs).Nested()
+}
+
+// Archs smaller implementation
+func (stgs smaller) Archs() []string {
+	return pipe(stgs).Archs()
+}
+
+// Usages smaller implementation
+func (stgs smaller) Usages() bool {
+	return pipe(stgs).Usages()
+}
@@ -0,0 +1,318 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestCond(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	terr := errors.New("test error")
+	o := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:  "a",
+				Size:  1,
+				Align: 1,
+			},
+			{
+				Name:  "b",
+				Size:  8,
+				Align: 8,
+			},
+			{
+				Name:  "c",
+				Size:  1,
+				Align: 1,
+			},
+		},
+	}
+	r := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:  "b",
+				Size:  8,
+				Align: 8,
+			},
+			{
+				Name:  "a",
+				Size:  1,
+				Align: 1,
+			},
+			{
+				Name:  "c",
+				Size:  1,
+				Align: 1,
+			},
+		},
+	}
+	table := map[string]struct {
+		cond cond
+		ctx  context.Context
+		o    gopium.Struct
+		r    gopium.Struct
+		err  error
+	}{
+		"empty struct should be applied to empty struct": {
+			cond: ifsize.Strategy(pck),
+			ctx:  context.Background(),
+		},
+		"struct should be applied to itself without inner strategy": {
+			cond: ifsize,
+			ctx:  context.Background(),
+			o:    o,
+			r:    o,
+		},
+		"struct should be applied accordingly to inner strategy on size greater than limit": {
+			cond: ifsize.Limit(16).Strategy(pck),
+			ctx:  context.Background(),
+			o:    o,
+			r:    r,
+		},
+		"struct should be applied to itself on size not greater than limit": {
+			cond: ifsize.Limit(24).Strategy(pck),
+			ctx:  context.Background(),
+			o:    o,
+			r:    o,
+		},
+		"struct should be applied accordingly to inner strategy on fields greater than limit": {
+			cond: iffields.Limit(2).Strategy(pck),
+			ctx:  context.Background(),
+			o:    o,
+			r:    r,
+		},
+		"struct should be applied to itself on fields not greater than limit": {
+			cond: iffields.Limit(3).Strategy(pck),
+			ctx:  context.Background(),
+			o:    o,
+			r:    o,
+		},
+		"struct should be applied accordingly to inner strategy on name match": {
+			cond: ifname.Regex(regexp.MustCompile(`^te`)).Strategy(pck),
+			ctx:  context.Background(),
+			o:    o,
+			r:    r,
+		},
+		"struct should be applied to itself on name mismatch": {
+			cond: ifname.Regex(regexp.MustCompile(`^st`)).Strategy(pck),
+			ctx:  context.Background(),
+			o:    o,
+			r:    o,
+		},
+		"struct should be applied to itself on name without regex": {
+			cond: ifname.Strategy(pck),
+			ctx:  context.Background(),
+			o:    o,
+			r:    o,
+		},
+		"struct should be applied to itself on canceled context": {
+			cond: ifsize.Limit(24).Strategy(pck),
+			ctx:  cctx,
+			o:    o,
+			r:    o,
+			err:  context.Canceled,
+		},
+		"struct should be applied to expected result on inner strategy error": {
+			cond: iffields.Strategy(&mocks.Strategy{Err: terr}),
+			ctx:  context.Background(),
+			o:    o,
+			err:  terr,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.cond.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestSmaller(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	terr := errors.New("test error")
+	o := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name:  "a",
+				Size:  1,
+				Align: 1,
+			},
+			{
+				Name:  "b",
+				Size:  8,
+				Align: 8,
+			},
+			{
+				Name:  "c",
+				Size:  1,
+				Align: 1,
+			},
+		},
+	}
+	table := map[string]struct {
+		smaller smaller
+		ctx     context.Context
+		o       gopium.Struct
+		r       gopium.Struct
+		err     error
+	}{
+		"empty struct should be applied to empty struct with empty smaller": {
+			ctx: context.Background(),
+		},
+		"struct should be applied to itself with empty smaller": {
+			ctx: context.Background(),
+			o:   o,
+			r:   o,
+		},
+		"struct should be applied accordingly to the smallest result": {
+			smaller: smaller{unpck, pck, fpad},
+			ctx:     context.Background(),
+			o:       o,
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "b",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "a",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "c",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"struct should be applied accordingly to the first result on ties": {
+			smaller: smaller{fpad, fnotecom},
+			ctx:     context.Background(),
+			o:       o,
+			r:       o,
+		},
+		"struct should be applied to itself on canceled context": {
+			smaller: smaller{pck},
+			ctx:     cctx,
+			o:       o,
+			r:       o,
+			err:     context.Canceled,
+		},
+		"struct should be applied to itself on inner strategy error": {
+			smaller: smaller{pck, &mocks.Strategy{Err: terr}},
+			ctx:     context.Background(),
+			o:       o,
+			r:       o,
+			err:     terr,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.smaller.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestCondNested(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		stg    gopium.NestedStrategy
+		nested bool
+	}{
+		"cond without inner strategy should not be nested": {
+			stg: ifsize,
+		},
+		"cond without nested inner strategy should not be nested": {
+			stg: iffields.Strategy(pck),
+		},
+		"cond with nested inner strategy should be nested": {
+			stg:    ifname.Strategy(pipe([]gopium.Strategy{pckdeep})),
+			nested: true,
+		},
+		"smaller without nested strategies should not be nested": {
+			stg: smaller{pck, unpck},
+		},
+		"smaller with nested strategy should be nested": {
+			stg:    smaller{pck, pckdeep},
+			nested: true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			nested := tcase.stg.Nested()
+			// check
+			if !reflect.DeepEqual(nested, tcase.nested) {
+				t.Errorf("actual %v doesn't equal to expected %v", nested, tcase.nested)
+			}
+		})
+	}
+}
+
+func TestCondUsages(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		stg    gopium.UsageStrategy
+		usages bool
+	}{
+		"cond without inner strategy should not require usages": {
+			stg: ifsize,
+		},
+		"cond without usage inner strategy should not require usages": {
+			stg: iffields.Strategy(pck),
+		},
+		"cond with usage inner strategy should require usages": {
+			stg:    ifname.Strategy(pipe([]gopium.Strategy{affinityl1})),
+			usages: true,
+		},
+		"smaller without usage strategies should not require usages": {
+			stg: smaller{pck, unpck},
+		},
+		"smaller with usage strategy should require usages": {
+			stg:    smaller{pck, atomic64},
+			usages: true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			usages := tcase.stg.Usages()
+			// check
+			if !reflect.DeepEqual(usages, tcase.usages) {
+				t.Errorf("actual %v doesn't equal to expected %v", usages, tcase.usages)
+			}
+		})
+	}
+}
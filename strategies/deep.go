@@ -0,0 +1,37 @@
+package strategies
+
+import (
+	"context"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of deep presets
+var (
+	pckdeep = deep{}
+)
+
+// deep defines strategy implementation
+// that rearranges structure fields
+// the same way as pack strategy does,
+// but also marks itself as nested strategy
+// so walkers apply it to package local nested
+// structures matching walker regex first
+// (other nested structures are kept intact
+// and only propagate their sizes) and then reevaluate parents
+// with nested structures final size, align and ptr;
+// as go doesn't allow recursive value types
+// nested structures always form acyclic graph
+// and children first order reaches stable layout
+// in a single run
+type deep struct{} // struct size: 0 bytes; struct align: 1 bytes; struct aligned size: 0 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Apply deep implementation
+func (stg deep) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	return pck.Apply(ctx, o)
+}
+
+// Nested deep implementation
+func (stg deep) Nested() bool {
+	return true
+}
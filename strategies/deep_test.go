@@ -0,0 +1,113 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestDeep(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		ctx context.Context
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to empty struct": {
+			ctx: context.Background(),
+		},
+		"non empty struct should be applied to itself on canceled context": {
+			ctx: cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"mixed struct should be applied to packed struct": {
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test.A",
+						Size:  16,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "test.A",
+						Size:  16,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test1",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := pckdeep.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if !pckdeep.Nested() {
+				t.Errorf("actual %v doesn't equal to expected %v", false, true)
+			}
+		})
+	}
+}
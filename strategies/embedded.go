@@ -0,0 +1,38 @@
+package strategies
+
+import (
+	"context"
+	"sort"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of embedded presets
+var (
+	embfirst = embedded{first: true}
+	emblast  = embedded{first: false}
+)
+
+// embedded defines strategy implementation
+// that sorts embedded fields
+// before or after not embedded fields
+type embedded struct {
+	first bool    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [1]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 2 bytes; struct align: 1 bytes; struct aligned size: 2 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Apply embedded implementation
+func (stg embedded) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// then execute embedded stable sorting
+	sort.SliceStable(r.Fields, func(i, j int) bool {
+		// sort depends on type of ordering
+		if stg.first {
+			return r.Fields[i].Embedded && !r.Fields[j].Embedded
+		}
+		return !r.Fields[i].Embedded && r.Fields[j].Embedded
+	})
+	return r, ctx.Err()
+}
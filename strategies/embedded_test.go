@@ -0,0 +1,166 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestEmbedded(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		embedded embedded
+		ctx      context.Context
+		o        gopium.Struct
+		r        gopium.Struct
+		err      error
+	}{
+		"empty struct should be applied to empty struct": {
+			embedded: embfirst,
+			ctx:      context.Background(),
+		},
+		"non empty struct should be applied to itself": {
+			embedded: embfirst,
+			ctx:      context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to itself on canceled context": {
+			embedded: emblast,
+			ctx:      cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"mixed embedded struct should be applied to stable sorted struct first": {
+			embedded: embfirst,
+			ctx:      context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test1",
+					},
+					{
+						Name:     "Test2",
+						Embedded: true,
+					},
+					{
+						Name: "test3",
+					},
+					{
+						Name:     "Test4",
+						Embedded: true,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:     "Test2",
+						Embedded: true,
+					},
+					{
+						Name:     "Test4",
+						Embedded: true,
+					},
+					{
+						Name: "test1",
+					},
+					{
+						Name: "test3",
+					},
+				},
+			},
+		},
+		"mixed embedded struct should be applied to stable sorted struct last": {
+			embedded: emblast,
+			ctx:      context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test1",
+					},
+					{
+						Name:     "Test2",
+						Embedded: true,
+					},
+					{
+						Name: "test3",
+					},
+					{
+						Name:     "Test4",
+						Embedded: true,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test1",
+					},
+					{
+						Name: "test3",
+					},
+					{
+						Name:     "Test2",
+						Embedded: true,
+					},
+					{
+						Name:     "Test4",
+						Embedded: true,
+					},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.embedded.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
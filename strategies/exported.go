@@ -0,0 +1,38 @@
+package strategies
+
+import (
+	"context"
+	"sort"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of exported presets
+var (
+	expfirst = exported{first: true}
+	explast  = exported{first: false}
+)
+
+// exported defines strategy implementation
+// that sorts exported fields
+// before or after unexported fields
+type exported struct {
+	first bool    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_     [1]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 2 bytes; struct align: 1 bytes; struct aligned size: 2 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Apply exported implementation
+func (stg exported) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// then execute exported stable sorting
+	sort.SliceStable(r.Fields, func(i, j int) bool {
+		// sort depends on type of ordering
+		if stg.first {
+			return r.Fields[i].Exported && !r.Fields[j].Exported
+		}
+		return !r.Fields[i].Exported && r.Fields[j].Exported
+	})
+	return r, ctx.Err()
+}
@@ -28,6 +28,7 @@ var (
 // note: supports only next fields tags annotation formats
 // `gopium:"stg,stg,stg"` processed as `default` group
 // `gopium:"group:def;stg,stg,stg"` processed as named group
+// tags markers like `gopium:"cold;stg,stg,stg"` are skipped
 type group struct {
 	builder Builder `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 } // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
@@ -78,12 +79,16 @@ func (stg group) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, err
 		container := &containers[i]
 		group.Go(func() error {
 			// apply strategy on struct
-			tmp, err := container.stg.Apply(gctx, collections.CopyStruct(container.o))
+			// without pinned fields markers
+			// as pins are resolved for whole struct
+			o, tokens := unpin(container.o)
+			tmp, err := container.stg.Apply(gctx, o)
 			// in case of any error
 			// just return error back
 			if err != nil {
 				return err
 			}
+			tmp = repin(tmp, tokens)
 			// in case of success
 			// update result on container
 			container.r = tmp
@@ -133,10 +138,15 @@ func (stg group) parse(st gopium.Struct) ([]container, error) {
 			gfields["-"] = append(gfields["-"], f)
 			continue
 		}
-		// trim all excess separators
-		tag = strings.Trim(tag, ";")
+		// skip all tag markers
+		// and in case tag contains only markers
+		// treat it as marked as skipped
+		_, tokens := collections.Marks(tag)
+		if len(tokens) == 0 {
+			gfields["-"] = append(gfields["-"], f)
+			continue
+		}
 		// otherwise parse the tag
-		tokens := strings.Split(tag, ";")
 		switch tlen := len(tokens); tlen {
 		case 1:
 			stgs := tokens[0]
@@ -181,7 +191,7 @@ func (stg group) parse(st gopium.Struct) ([]container, error) {
 	// and build pipe strategy from them
 	for grp, gstgs := range gstrategiesnames {
 		// prepare strategy pipe
-		names := strings.Split(gstgs, ",")
+		names := splita(gstgs)
 		p := make(pipe, 0, len(names))
 		// go through list of strategy name
 		for _, name := range names {
@@ -177,6 +177,137 @@ func TestGroup(t *testing.T) {
 				},
 			},
 		},
+		"non empty struct with relevant marked group tag should be applied to expected struct": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"cold;group:def;fields_annotate_doc"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"cold;group:def;fields_annotate_doc"`,
+						Doc:   []string{"// field size: 8 bytes; field align: 4 bytes; field ptr: 0 bytes; - 🌺 gopium @1pkg"},
+					},
+				},
+			},
+		},
+		"non empty struct with pin markers tag should be applied to expected struct ignoring group local pins": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test-1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"pin:index=2;memory_pack"`,
+					},
+					{
+						Name:  "test-2",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"memory_pack"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test-2",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"memory_pack"`,
+					},
+					{
+						Name:  "test-1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"pin:index=2;memory_pack"`,
+					},
+				},
+			},
+		},
+		"non empty struct with isolate markers tag should be applied to expected isolated struct": {
+			b:   Builder{Curator: mocks.Maven{SCache: []int64{16}}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"isolate;group:def;false_sharing_isolate_cpu_l1"`,
+					},
+					{
+						Name:  "test2",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"group:def;false_sharing_isolate_cpu_l1"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"group:def;false_sharing_isolate_cpu_l1"`,
+					},
+					collections.PadField(12),
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"isolate;group:def;false_sharing_isolate_cpu_l1"`,
+					},
+					collections.PadField(8),
+				},
+			},
+		},
+		"non empty struct with only markers tag should be applied to itself": {
+			b:   Builder{Curator: mocks.Maven{}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"cold"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 4,
+						Tag:   `gopium:"cold"`,
+					},
+				},
+			},
+		},
 		"non empty struct with invalid tag should be applied to itself with error": {
 			b:   Builder{Curator: mocks.Maven{}},
 			ctx: context.Background(),
@@ -0,0 +1,164 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of heat presets
+var (
+	heatl1 = heat{line: 1}
+	heatl2 = heat{line: 2}
+	heatl3 = heat{line: 3}
+	heatb  = heat{}
+)
+
+// heat defines strategy implementation
+// that places structure fields by their heat,
+// fields are split to tiers by heat weight
+// from `gopium:"hot"`, `gopium:"warm"`,
+// `gopium:"cold"` or `gopium:"heat:N"` tag markers
+// or from fields profile heat if no markers found,
+// each tier is packed internally and tiers are placed
+// from the hottest to the coldest one,
+// tier is moved to the next cpu cache line
+// if it reduces number of cache lines tier spans
+type heat struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	line    uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bytes   uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Bytes erich heat strategy with custom bytes
+func (stg heat) Bytes(bytes uint) heat {
+	stg.bytes = bytes
+	return stg
+}
+
+// Curator erich heat strategy with curator instance
+func (stg heat) Curator(curator gopium.Curator) heat {
+	stg.curator = curator
+	return stg
+}
+
+// Usages heat implementation
+func (stg heat) Usages() bool {
+	return true
+}
+
+// Apply heat implementation
+func (stg heat) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// check that struct has fields
+	// and cache line size or bytes are valid
+	flen, cachel := len(r.Fields), stg.curator.SysCache(stg.line)
+	if stg.line == 0 {
+		cachel = int64(stg.bytes)
+	}
+	if flen == 0 || cachel <= 0 {
+		return r, ctx.Err()
+	}
+	// find the hottest field profile heat
+	var hmax int64
+	for _, f := range r.Fields {
+		if f.Heat > hmax {
+			hmax = f.Heat
+		}
+	}
+	// split fields to tiers by heat weight
+	tiers := make(map[uint][]gopium.Field)
+	weights := make([]uint, 0, flen)
+	for _, f := range r.Fields {
+		w, err := hweight(r.Name, f, hmax)
+		if err != nil {
+			return o, err
+		}
+		if _, ok := tiers[w]; !ok {
+			weights = append(weights, w)
+		}
+		tiers[w] = append(tiers[w], f)
+	}
+	sort.Slice(weights, func(i, j int) bool {
+		return weights[i] > weights[j]
+	})
+	// go through all tiers from the hottest one
+	// pack each of them and place them one by one
+	fields := make([]gopium.Field, 0, flen)
+	for _, w := range weights {
+		tier := tiers[w]
+		sort.SliceStable(tier, func(i, j int) bool {
+			return packless(tier[i], tier[j])
+		})
+		// move the tier to the next cache line
+		// only if it spans fewer cache lines there
+		if pad := offset(fields) % cachel; pad > 0 {
+			padded := append(fields[:len(fields):len(fields)], collections.PadField(cachel-pad))
+			if hlines(padded, tier, cachel) < hlines(fields, tier, cachel) {
+				fields = padded
+			}
+		}
+		fields = append(fields, tier...)
+	}
+	// update resulted fields
+	r.Fields = fields
+	return r, ctx.Err()
+}
+
+// hweight returns field heat weight
+// from field heat tag markers:
+// `heat:N` -> N, `hot` -> 3, `warm` -> 2,
+// no marker -> 1, `cold` -> 0,
+// if no markers found and structure has profile heat
+// field heat relative to the hottest field is used:
+// at least half -> 3, at least eighth -> 2,
+// any other heat -> 1, no heat -> 0
+func hweight(name string, f gopium.Field, hmax int64) (uint, error) {
+	if val, ok := collections.Marker(f.Tag, collections.MarkHeat); ok {
+		w, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("field %q in struct %q has invalid heat marker %q", f.Name, name, val)
+		}
+		return uint(w), nil
+	}
+	if _, ok := collections.Marker(f.Tag, collections.MarkHot); ok {
+		return 3, nil
+	}
+	if _, ok := collections.Marker(f.Tag, collections.MarkWarm); ok {
+		return 2, nil
+	}
+	if _, ok := collections.Marker(f.Tag, collections.MarkCold); ok {
+		return 0, nil
+	}
+	switch {
+	case hmax <= 0:
+		return 1, nil
+	case f.Heat > 0 && f.Heat*2 >= hmax:
+		return 3, nil
+	case f.Heat > 0 && f.Heat*8 >= hmax:
+		return 2, nil
+	case f.Heat > 0:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// hlines calculates number of cache lines
+// spanned by the tier placed after provided fields
+func hlines(fields []gopium.Field, tier []gopium.Field, cachel int64) int64 {
+	start := offset(fields)
+	if len(tier) > 0 && tier[0].Align > 0 {
+		start = collections.Align(start, tier[0].Align)
+	}
+	end := offset(append(fields[:len(fields):len(fields)], tier...))
+	if end <= start {
+		return 0
+	}
+	return (end-1)/cachel - start/cachel + 1
+}
@@ -0,0 +1,365 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestHeat(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		heat heat
+		c    gopium.Curator
+		ctx  context.Context
+		o    gopium.Struct
+		r    gopium.Struct
+		err  error
+	}{
+		"empty struct should be applied to empty struct": {
+			heat: heatl1,
+			c:    mocks.Maven{SCache: []int64{16}},
+			ctx:  context.Background(),
+		},
+		"non empty struct should be applied to itself on empty custom bytes and empty line": {
+			heat: heatb,
+			c:    mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"cold"`,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"hot"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"cold"`,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"hot"`,
+					},
+				},
+			},
+		},
+		"non empty struct with profile heat should be applied to expected heat tiers struct": {
+			heat: heatl1,
+			c:    mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Size:  8,
+						Align: 8,
+						Heat:  100,
+					},
+					{
+						Name:  "c",
+						Size:  4,
+						Align: 4,
+						Heat:  20,
+					},
+					{
+						Name:  "d",
+						Size:  2,
+						Align: 2,
+						Heat:  5,
+					},
+					{
+						Name:  "e",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"hot"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "b",
+						Size:  8,
+						Align: 8,
+						Heat:  100,
+					},
+					{
+						Name:  "e",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"hot"`,
+					},
+					{
+						Name:  "c",
+						Size:  4,
+						Align: 4,
+						Heat:  20,
+					},
+					{
+						Name:  "d",
+						Size:  2,
+						Align: 2,
+						Heat:  5,
+					},
+					{
+						Name:  "a",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"non empty struct without markers should be applied to packed struct": {
+			heat: heatl1,
+			c:    mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to expected heat tiers struct": {
+			heat: heatl2,
+			c:    mocks.Maven{SCache: []int64{32, 16, 64}},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"cold"`,
+					},
+					{
+						Name:  "test2",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"hot;group:def;memory_pack"`,
+					},
+					{
+						Name:  "test4",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test5",
+						Size:  2,
+						Align: 2,
+						Tag:   `gopium:"heat:5"`,
+					},
+					{
+						Name:  "test6",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test7",
+						Size:  2,
+						Align: 2,
+						Tag:   `gopium:"warm"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test5",
+						Size:  2,
+						Align: 2,
+						Tag:   `gopium:"heat:5"`,
+					},
+					{
+						Name:  "test3",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"hot;group:def;memory_pack"`,
+					},
+					{
+						Name:  "test7",
+						Size:  2,
+						Align: 2,
+						Tag:   `gopium:"warm"`,
+					},
+					collections.PadField(10),
+					{
+						Name:  "test6",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test4",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"cold"`,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to expected heat tiers struct on canceled context": {
+			heat: heatb.Bytes(8),
+			c:    mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:  cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"hot"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"hot"`,
+					},
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"non empty struct with invalid heat marker should return error": {
+			heat: heatl1,
+			c:    mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"heat:high"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"heat:high"`,
+					},
+				},
+			},
+			err: errors.New(`field "test1" in struct "test" has invalid heat marker "high"`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			heat := tcase.heat.Curator(tcase.c)
+			// exec
+			r, err := heat.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
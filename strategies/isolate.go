@@ -0,0 +1,112 @@
+package strategies
+
+import (
+	"context"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of isolate presets
+var (
+	isolatel1 = isolate{line: 1}
+	isolatel2 = isolate{line: 2}
+	isolatel3 = isolate{line: 3}
+	isolateb  = isolate{}
+)
+
+// isolate defines strategy implementation
+// that guards only contended structure fields
+// from false sharing by isolating them
+// on separate cpu cache lines,
+// fields are marked as contended either
+// by `gopium:"isolate"` tag marker to be isolated alone
+// or by `gopium:"isolate:name"` tag marker
+// to be isolated together with the same name fields,
+// the rest of fields are kept packed together
+// before all isolated fields
+type isolate struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	line    uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bytes   uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Bytes erich isolate strategy with custom bytes
+func (stg isolate) Bytes(bytes uint) isolate {
+	stg.bytes = bytes
+	return stg
+}
+
+// Curator erich isolate strategy with curator instance
+func (stg isolate) Curator(curator gopium.Curator) isolate {
+	stg.curator = curator
+	return stg
+}
+
+// Apply isolate implementation
+func (stg isolate) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// check that struct has fields
+	// and cache line size or bytes are valid
+	if flen, cachel := len(r.Fields), stg.curator.SysCache(stg.line); flen > 0 && (cachel > 0 || stg.bytes > 0) {
+		if stg.line == 0 {
+			cachel = int64(stg.bytes)
+		}
+		// split fields to packed fields
+		// and isolated fields units
+		// keeping original fields order
+		fields := make([]gopium.Field, 0, flen)
+		var units [][]gopium.Field
+		names := make(map[string]int)
+		for _, f := range r.Fields {
+			name, ok := collections.Marker(f.Tag, collections.MarkIsolate)
+			switch {
+			case !ok:
+				fields = append(fields, f)
+			case name == "":
+				units = append(units, []gopium.Field{f})
+			default:
+				if i, ok := names[name]; ok {
+					units[i] = append(units[i], f)
+					continue
+				}
+				names[name] = len(units)
+				units = append(units, []gopium.Field{f})
+			}
+		}
+		// in case there is nothing to isolate
+		// just return result back
+		if len(units) == 0 {
+			return r, ctx.Err()
+		}
+		// go through all isolated units
+		// and place each of them on separate
+		// cache lines by padding around
+		for _, unit := range units {
+			if pad := offset(fields) % cachel; pad > 0 {
+				fields = append(fields, collections.PadField(cachel-pad))
+			}
+			fields = append(fields, unit...)
+		}
+		if pad := offset(fields) % cachel; pad > 0 {
+			fields = append(fields, collections.PadField(cachel-pad))
+		}
+		// update resulted fields
+		r.Fields = fields
+	}
+	return r, ctx.Err()
+}
+
+// offset calculates end offset
+// of the last provided field
+func offset(fields []gopium.Field) int64 {
+	var off int64
+	for _, f := range fields {
+		if f.Align > 0 {
+			off = collections.Align(off, f.Align)
+		}
+		off += f.Size
+	}
+	return off
+}
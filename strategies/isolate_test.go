@@ -0,0 +1,294 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestIsolate(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		isolate isolate
+		c       gopium.Curator
+		ctx     context.Context
+		o       gopium.Struct
+		r       gopium.Struct
+		err     error
+	}{
+		"empty struct should be applied to empty struct": {
+			isolate: isolatel1,
+			c:       mocks.Maven{SCache: []int64{32}},
+			ctx:     context.Background(),
+		},
+		"non empty struct without markers should be applied to itself": {
+			isolate: isolatel1,
+			c:       mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"cold"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"cold"`,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to expected isolated struct": {
+			isolate: isolatel1,
+			c:       mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"isolate"`,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+					},
+					collections.PadField(4),
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"isolate"`,
+					},
+					collections.PadField(15),
+				},
+			},
+		},
+		"non empty struct should be applied to expected isolated struct on canceled context": {
+			isolate: isolatel3,
+			c:       mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:     cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"isolate"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"isolate"`,
+					},
+					collections.PadField(8),
+				},
+			},
+			err: context.Canceled,
+		},
+		"non empty struct should be applied to itself on empty custom bytes and empty line": {
+			isolate: isolateb,
+			c:       mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"isolate"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  8,
+						Align: 8,
+						Tag:   `gopium:"isolate"`,
+					},
+				},
+			},
+		},
+		"mixed struct should be applied to expected isolated struct": {
+			isolate: isolatel2,
+			c:       mocks.Maven{SCache: []int64{8, 16, 32}},
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"isolate:cnt;group:def;memory_pack"`,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"isolate:cnt"`,
+					},
+					{
+						Name:  "test4",
+						Size:  2,
+						Align: 2,
+						Tag:   `gopium:"isolate"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+					collections.PadField(8),
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"isolate:cnt;group:def;memory_pack"`,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"isolate:cnt"`,
+					},
+					collections.PadField(8),
+					{
+						Name:  "test4",
+						Size:  2,
+						Align: 2,
+						Tag:   `gopium:"isolate"`,
+					},
+					collections.PadField(14),
+				},
+			},
+		},
+		"mixed struct should be applied to expected isolated struct custom bytes": {
+			isolate: isolateb.Bytes(12),
+			c:       mocks.Maven{SCache: []int64{16, 32, 64}},
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"isolate"`,
+					},
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"isolate"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"isolate"`,
+					},
+					collections.PadField(11),
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"isolate"`,
+					},
+					collections.PadField(11),
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			isolate := tcase.isolate.Curator(tcase.c)
+			// exec
+			r, err := isolate.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
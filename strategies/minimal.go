@@ -0,0 +1,320 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of minimal presets
+var (
+	pckmin  = minimal{budget: 1 << 20}
+	pckminb = minimal{budget: 1 << 20}
+)
+
+// minimal defines strategy implementation
+// that rearranges structure fields
+// to obtain pack struct size (or provided bytes size)
+// by moving as few fields as possible
+// from their original positions,
+// number of moved fields is annotated in struct comment;
+// search is limited by states budget and in case
+// the budget is exhausted or the size can't be reached
+// pack layout is used instead, unreached size
+// is annotated in struct comment as well
+type minimal struct {
+	bytes  uint `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	budget uint `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Bytes erich minimal strategy with custom target size
+func (stg minimal) Bytes(bytes uint) minimal {
+	stg.bytes = bytes
+	return stg
+}
+
+// Budget erich minimal strategy with custom search budget
+func (stg minimal) Budget(budget uint) minimal {
+	stg.budget = budget
+	return stg
+}
+
+// Apply minimal implementation
+func (stg minimal) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// start from greedy pack layout
+	// which defines default size target
+	p, err := pck.Apply(ctx, o)
+	// in case of any error
+	// just return error back
+	if err != nil {
+		return o, err
+	}
+	target := int64(stg.bytes)
+	if target == 0 {
+		target = msize(p.Fields)
+	}
+	// run bounded moves search
+	// and use its layout if target is reached
+	// otherwise fallback to pack layout
+	if fields, ok := newmoves(r.Fields, stg.budget).find(target); ok {
+		r.Fields = fields
+	} else {
+		r.Fields = p.Fields
+	}
+	// note structure with moved fields comment
+	// and unreached size target if any
+	note := fmt.Sprintf(
+		"// struct fields moved: %d; - %s",
+		mcount(o.Fields, r.Fields),
+		gopium.STAMP,
+	)
+	if size := msize(r.Fields); size > target {
+		note = fmt.Sprintf(
+			"// struct fields moved: %d; struct size target: %d bytes isn't reached, struct size: %d bytes; - %s",
+			mcount(o.Fields, r.Fields),
+			target,
+			size,
+			gopium.STAMP,
+		)
+	}
+	r.Comment = append(r.Comment, note)
+	return r, ctx.Err()
+}
+
+// moves defines minimal moves fields layout search
+// which tries to move fields subsets in increasing size order
+// and places moved fields among kept fields
+// with dynamic programming over minimal offsets
+type moves struct {
+	fields []gopium.Field `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	budget uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	align  int64          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [24]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 8 bytes; - 🌺 gopium @1pkg
+
+// newmoves creates moves search instance
+// from provided original ordered fields
+func newmoves(fields []gopium.Field, budget uint) *moves {
+	m := &moves{fields: fields, budget: budget, align: 1}
+	for _, f := range fields {
+		if f.Align > m.align {
+			m.align = f.Align
+		}
+	}
+	return m
+}
+
+// find searches for layout with the least moved fields
+// which aligned size doesn't exceed provided target
+func (m *moves) find(target int64) ([]gopium.Field, bool) {
+	// check that target is reachable at all
+	var size int64
+	for _, f := range m.fields {
+		size += f.Size
+	}
+	if collections.Align(size, m.align) > target {
+		return nil, false
+	}
+	// try to move smaller fields first
+	// as they fill holes most naturally
+	idx := mrange(len(m.fields))
+	sort.SliceStable(idx, func(i, j int) bool {
+		return m.fields[idx[i]].Size < m.fields[idx[j]].Size
+	})
+	// go through all moved subsets sizes
+	for k := 0; k <= len(idx); k++ {
+		comb := make([]int, k)
+		for i := range comb {
+			comb[i] = i
+		}
+		for {
+			// split fields to kept and moved
+			moved := make([]bool, len(m.fields))
+			mv := make([]int, 0, k)
+			for _, c := range comb {
+				moved[idx[c]] = true
+				mv = append(mv, idx[c])
+			}
+			kept := make([]int, 0, len(m.fields)-k)
+			for i := range m.fields {
+				if !moved[i] {
+					kept = append(kept, i)
+				}
+			}
+			order, ok := m.place(kept, mv)
+			// in case budget is exhausted
+			// stop the search
+			if !ok {
+				return nil, false
+			}
+			if m.size(order) <= target {
+				var fields []gopium.Field
+				for _, i := range order {
+					fields = append(fields, collections.CopyField(m.fields[i]))
+				}
+				return fields, true
+			}
+			// go to next subset of the same size
+			if !mnext(comb, len(idx)) {
+				break
+			}
+		}
+	}
+	return nil, false
+}
+
+// place finds order with minimal size
+// that keeps kept fields relative order
+// and puts moved fields anywhere between them
+func (m *moves) place(kept []int, mv []int) ([]int, bool) {
+	// check that search budget
+	// is enough for all states
+	full := 1 << len(mv)
+	states := uint((len(kept) + 1) * full)
+	if len(mv) >= 32 || states > m.budget {
+		m.budget = 0
+		return nil, false
+	}
+	m.budget -= states
+	// offsets holds minimal offset for state
+	// of placed kept fields count and moved fields mask,
+	// choices holds last placed field for state
+	offsets, choices := make([]int64, states), make([]int, states)
+	for i := range offsets {
+		offsets[i] = -1
+	}
+	offsets[0] = 0
+	for i := 0; i <= len(kept); i++ {
+		for mask := 0; mask < full; mask++ {
+			offset := offsets[i*full+mask]
+			if offset < 0 {
+				continue
+			}
+			// place next kept field
+			if i < len(kept) {
+				m.relax(offsets, choices, (i+1)*full+mask, m.next(kept[i], offset), kept[i])
+			}
+			// place any remaining moved field
+			for b, f := range mv {
+				if mask&(1<<b) == 0 {
+					m.relax(offsets, choices, i*full+(mask|1<<b), m.next(f, offset), f)
+				}
+			}
+		}
+	}
+	// restore order from the final state
+	order := make([]int, len(kept)+len(mv))
+	i, mask := len(kept), full-1
+	for p := len(order) - 1; p >= 0; p-- {
+		f := choices[i*full+mask]
+		order[p] = f
+		if i > 0 && kept[i-1] == f {
+			i--
+			continue
+		}
+		for b := range mv {
+			if mv[b] == f {
+				mask &^= 1 << b
+				break
+			}
+		}
+	}
+	return order, true
+}
+
+// relax updates state offset and choice
+// if provided offset is smaller
+func (m *moves) relax(offsets []int64, choices []int, state int, offset int64, f int) {
+	if offsets[state] < 0 || offset < offsets[state] {
+		offsets[state] = offset
+		choices[state] = f
+	}
+}
+
+// next calculates offset right after
+// the field placed after provided offset
+func (m *moves) next(i int, offset int64) int64 {
+	f := m.fields[i]
+	if f.Align > 0 {
+		offset = collections.Align(offset, f.Align)
+	}
+	return offset + f.Size
+}
+
+// size calculates struct aligned size
+// for the provided fields order
+func (m *moves) size(order []int) int64 {
+	var offset int64
+	for _, i := range order {
+		offset = m.next(i, offset)
+	}
+	return collections.Align(offset, m.align)
+}
+
+// mnext advances combination to the next one
+// in lexicographic order, returns false if
+// there are no combinations left
+func mnext(comb []int, n int) bool {
+	k := len(comb)
+	for i := k - 1; i >= 0; i-- {
+		if comb[i] < n-k+i {
+			comb[i]++
+			for j := i + 1; j < k; j++ {
+				comb[j] = comb[j-1] + 1
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// msize calculates struct aligned size
+// for the provided fields
+func msize(fields []gopium.Field) int64 {
+	return newmoves(fields, 0).size(mrange(len(fields)))
+}
+
+// mrange returns identity fields order
+func mrange(n int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+// mcount calculates number of moved fields
+// between original and result fields
+// as fields not included into
+// the longest kept in order fields sequence
+func mcount(o []gopium.Field, r []gopium.Field) int {
+	// match result fields with original fields
+	used := make([]bool, len(o))
+	idx := make([]int, 0, len(r))
+	for _, rf := range r {
+		for i, of := range o {
+			if !used[i] && of.Name == rf.Name && of.Type == rf.Type {
+				used[i] = true
+				idx = append(idx, i)
+				break
+			}
+		}
+	}
+	// find the longest increasing
+	// original indexes sequence
+	var tails []int
+	for _, i := range idx {
+		p := sort.SearchInts(tails, i)
+		if p == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[p] = i
+		}
+	}
+	return len(r) - len(tails)
+}
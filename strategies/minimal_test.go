@@ -0,0 +1,434 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestMinimal(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		minimal minimal
+		ctx     context.Context
+		o       gopium.Struct
+		r       gopium.Struct
+		err     error
+	}{
+		"empty struct should be applied to empty struct": {
+			minimal: pckmin,
+			ctx:     context.Background(),
+			r: gopium.Struct{
+				Comment: []string{
+					"// struct fields moved: 0; - 🌺 gopium @1pkg",
+				},
+			},
+		},
+		"non empty struct should be applied to itself on canceled context": {
+			minimal: pckmin,
+			ctx:     cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"packed struct should be applied to itself": {
+			minimal: pckmin,
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Comment: []string{
+					"// struct fields moved: 0; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"struct with holes should be applied to struct with single moved field": {
+			minimal: pckmin,
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Comment: []string{
+					"// struct fields moved: 1; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"struct with holes should be applied to itself on reached bytes size": {
+			minimal: pckminb.Bytes(32),
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Comment: []string{
+					"// struct fields moved: 0; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"struct with holes should be applied to pack struct on unreachable bytes size": {
+			minimal: pckminb.Bytes(16),
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Comment: []string{
+					"// struct fields moved: 2; struct size target: 16 bytes isn't reached, struct size: 24 bytes; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"struct with holes should be applied to pack struct on exhausted budget": {
+			minimal: pckmin.Budget(1),
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Comment: []string{
+					"// struct fields moved: 2; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.minimal.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -14,16 +14,29 @@ var (
 	fnotecom  = note{doc: false, field: true}
 	stnotedoc = note{doc: true, field: false}
 	stnotecom = note{doc: false, field: false}
+	fnotedoco = note{doc: true, field: true, offset: true}
+	fnotecomo = note{doc: false, field: true, offset: true}
 )
 
 // note defines strategy implementation
 // that adds size doc or comment annotation
-// for each structure field
+// for each structure field, optionally with
+// field offset, padding before the field
+// and cpu cache line #1 indexes it occupies,
 // and aggregated size annotation for structure
 type note struct {
-	doc   bool `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	field bool `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 2 bytes; struct align: 1 bytes; struct aligned size: 2 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	doc     bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	field   bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	offset  bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [13]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Curator erich note strategy with curator instance
+func (stg note) Curator(curator gopium.Curator) note {
+	stg.curator = curator
+	return stg
+}
 
 // Apply note implementation
 func (stg note) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
@@ -42,13 +55,27 @@ func (stg note) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, erro
 				// note only in field mode
 				if stg.field {
 					// create note comment
-					note := fmt.Sprintf(
-						"// field size: %d bytes; field align: %d bytes; field ptr: %d bytes; - %s",
-						f.Size,
-						f.Align,
-						f.Ptr,
-						gopium.STAMP,
-					)
+					var note string
+					if stg.offset {
+						note = fmt.Sprintf(
+							"// field size: %d bytes; field align: %d bytes; field ptr: %d bytes; field offset: %d bytes; field pad before: %d bytes;%s - %s",
+							f.Size,
+							f.Align,
+							f.Ptr,
+							alsize,
+							pad,
+							stg.lines(alsize, f.Size),
+							gopium.STAMP,
+						)
+					} else {
+						note = fmt.Sprintf(
+							"// field size: %d bytes; field align: %d bytes; field ptr: %d bytes; - %s",
+							f.Size,
+							f.Align,
+							f.Ptr,
+							gopium.STAMP,
+						)
+					}
 					if stg.doc {
 						f.Doc = append(f.Doc, note)
 					} else {
@@ -100,3 +127,24 @@ func (stg note) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, erro
 	}
 	return r, ctx.Err()
 }
+
+// lines formats cpu cache line #1 indexes
+// occupied by field with provided offset and size,
+// nothing is formatted on invalid cache line size
+func (stg note) lines(offset int64, size int64) string {
+	if stg.curator == nil {
+		return ""
+	}
+	cachel := stg.curator.SysCache(1)
+	if cachel <= 0 {
+		return ""
+	}
+	first, last := offset/cachel, offset/cachel
+	if size > 0 {
+		last = (offset + size - 1) / cachel
+	}
+	if first == last {
+		return fmt.Sprintf(" field cpu l1 lines: %d;", first)
+	}
+	return fmt.Sprintf(" field cpu l1 lines: %d-%d;", first, last)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
 )
 
 func TestNote(t *testing.T) {
@@ -436,6 +437,97 @@ func TestNote(t *testing.T) {
 				},
 			},
 		},
+		"complex struct should be applied to itself with expected offsets comment fields": {
+			note: fnotecomo.Curator(mocks.Maven{SCache: []int64{8}}),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "byte",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   8,
+					},
+					{
+						Name:  "test3",
+						Type:  "[3]int32",
+						Size:  12,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:    "test1",
+						Type:    "byte",
+						Size:    1,
+						Align:   1,
+						Comment: []string{"// field size: 1 bytes; field align: 1 bytes; field ptr: 0 bytes; field offset: 0 bytes; field pad before: 0 bytes; field cpu l1 lines: 0; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "test2",
+						Type:    "string",
+						Size:    16,
+						Align:   8,
+						Ptr:     8,
+						Comment: []string{"// field size: 16 bytes; field align: 8 bytes; field ptr: 8 bytes; field offset: 8 bytes; field pad before: 7 bytes; field cpu l1 lines: 1-2; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:    "test3",
+						Type:    "[3]int32",
+						Size:    12,
+						Align:   4,
+						Comment: []string{"// field size: 12 bytes; field align: 4 bytes; field ptr: 0 bytes; field offset: 24 bytes; field pad before: 0 bytes; field cpu l1 lines: 3-4; - 🌺 gopium @1pkg"},
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to itself with expected offsets doc fields without cache lines": {
+			note: fnotedoco.Curator(mocks.Maven{}),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  2,
+						Align: 2,
+					},
+					{
+						Name:  "test2",
+						Size:  0,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  2,
+						Align: 2,
+						Doc:   []string{"// field size: 2 bytes; field align: 2 bytes; field ptr: 0 bytes; field offset: 0 bytes; field pad before: 0 bytes; - 🌺 gopium @1pkg"},
+					},
+					{
+						Name:  "test2",
+						Size:  0,
+						Align: 4,
+						Doc:   []string{"// field size: 0 bytes; field align: 4 bytes; field ptr: 0 bytes; field offset: 4 bytes; field pad before: 2 bytes; - 🌺 gopium @1pkg"},
+					},
+				},
+			},
+		},
 	}
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
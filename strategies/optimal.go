@@ -0,0 +1,275 @@
+package strategies
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of optimal presets
+var (
+	pckopt  = optimal{budget: 1 << 16}
+	pckoptb = optimal{}
+)
+
+// optimal defines strategy implementation
+// that rearranges structure fields
+// to obtain minimal possible struct size
+// (with minimal ptr scan size as a tie breaker)
+// by exploring fields permutations with branch and bound,
+// search is limited by nodes budget and in case
+// the budget is exhausted the best found layout is used,
+// which is never worse than greedy pack layout
+type optimal struct {
+	budget uint `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 8 bytes; struct align: 8 bytes; struct aligned size: 8 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Budget erich optimal strategy with custom search budget
+func (stg optimal) Budget(budget uint) optimal {
+	stg.budget = budget
+	return stg
+}
+
+// Apply optimal implementation
+func (stg optimal) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// start from greedy pack layout
+	// which is used as initial best layout
+	r, err := pck.Apply(ctx, o)
+	// in case of any error
+	// just return error back
+	if err != nil {
+		return r, err
+	}
+	// run bounded layout search
+	// and use its best found layout
+	s := newsearch(r.Fields, stg.budget, false)
+	s.dfs(0, 0)
+	r.Fields = s.fields()
+	return r, ctx.Err()
+}
+
+// search defines branch and bound fields layout search
+// which operates on classes of identical fields
+// (same size, align and ptr) to avoid exploring
+// equivalent permutations, search is seeded with
+// provided greedy layout and never exceeds its size;
+// by default layouts are compared by size and then by ptr scan size,
+// in ptr first mode they are compared by ptr scan size and then by size
+type search struct {
+	memo     map[string]int64 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	classes  [][]gopium.Field `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	counts   []int            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	order    []int            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	best     []int            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	budget   uint             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	align    int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	rsize    int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	rptrs    int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	minptr   int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	limit    int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bsize    int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bptr     int64            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ptrfirst bool             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [23]byte         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 192 bytes; struct align: 8 bytes; struct aligned size: 192 bytes; struct ptr scan size: 88 bytes; - 🌺 gopium @1pkg
+
+// newsearch creates search instance
+// from provided greedy ordered fields
+func newsearch(fields []gopium.Field, budget uint, ptrfirst bool) *search {
+	s := &search{
+		memo:     make(map[string]int64),
+		budget:   budget,
+		align:    1,
+		ptrfirst: ptrfirst,
+	}
+	// go through all fields
+	// and collect them into classes
+	seed := make([]int, 0, len(fields))
+	for i, f := range fields {
+		// identical fields are adjacent
+		// after greedy pack sorting
+		if l := len(s.classes); i > 0 && l > 0 && s.identical(s.classes[l-1][0], f) {
+			s.classes[l-1] = append(s.classes[l-1], f)
+		} else {
+			s.classes = append(s.classes, []gopium.Field{f})
+		}
+		seed = append(seed, len(s.classes)-1)
+		// collect search bounds helpers
+		s.rsize += f.Size
+		if f.Align > s.align {
+			s.align = f.Align
+		}
+		if f.Ptr > 0 {
+			s.rptrs++
+			if s.minptr == 0 || f.Ptr < s.minptr {
+				s.minptr = f.Ptr
+			}
+		}
+	}
+	// in ptr first mode explore
+	// pointerful classes first
+	if ptrfirst {
+		idx := make([]int, len(s.classes))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(i, j int) bool {
+			return s.classes[idx[i]][0].Ptr > 0 && s.classes[idx[j]][0].Ptr == 0
+		})
+		classes, remap := make([][]gopium.Field, len(idx)), make([]int, len(idx))
+		for i, ci := range idx {
+			classes[i], remap[ci] = s.classes[ci], i
+		}
+		for i := range seed {
+			seed[i] = remap[seed[i]]
+		}
+		s.classes = classes
+	}
+	s.counts = make([]int, len(s.classes))
+	for i, class := range s.classes {
+		s.counts[i] = len(class)
+	}
+	// seed the best layout with greedy layout
+	// and use its size as the search limit
+	s.best = seed
+	s.bsize, s.bptr = s.eval(seed)
+	s.limit = s.bsize
+	return s
+}
+
+// identical checks if two fields are
+// indistinguishable from layout point of view
+func (s *search) identical(f1, f2 gopium.Field) bool {
+	return f1.Size == f2.Size && f1.Align == f2.Align && f1.Ptr == f2.Ptr
+}
+
+// place calculates field offset and updated ptr scan size
+// for the field placed after provided offset and ptr scan size
+func (s *search) place(f gopium.Field, offset int64, ptr int64) (int64, int64) {
+	if f.Align > 0 {
+		offset = collections.Align(offset, f.Align)
+	}
+	if f.Ptr > 0 {
+		ptr = offset + f.Ptr
+	}
+	return offset, ptr
+}
+
+// eval calculates struct size and ptr scan size
+// for the provided classes order
+func (s *search) eval(order []int) (int64, int64) {
+	var offset, ptr int64
+	for _, i := range order {
+		f := s.classes[i][0]
+		offset, ptr = s.place(f, offset, ptr)
+		offset += f.Size
+	}
+	return collections.Align(offset, s.align), ptr
+}
+
+// better checks if provided size and ptr scan size
+// are better than the best found ones
+func (s *search) better(size int64, ptr int64) bool {
+	if s.ptrfirst {
+		return size <= s.limit && (ptr < s.bptr || (ptr == s.bptr && size < s.bsize))
+	}
+	return size < s.bsize || (size == s.bsize && ptr < s.bptr)
+}
+
+// dfs explores layouts started from
+// provided offset and ptr scan size
+func (s *search) dfs(offset int64, ptr int64) {
+	// check that search budget
+	// is not exhausted yet
+	if s.budget == 0 {
+		return
+	}
+	s.budget--
+	// in case all fields are placed
+	// check if layout is better than best one
+	if s.complete() {
+		if size := collections.Align(offset, s.align); s.better(size, ptr) {
+			s.bsize, s.bptr = size, ptr
+			s.best = append(s.best[:0], s.order...)
+		}
+		return
+	}
+	// calculate lower bounds
+	// and prune branch if it
+	// can't be better than best one
+	lsize, lptr := collections.Align(offset+s.rsize, s.align), ptr
+	if s.rptrs > 0 && offset+s.minptr > lptr {
+		lptr = offset + s.minptr
+	}
+	if !s.better(lsize, lptr) {
+		return
+	}
+	// check if the same state with
+	// smaller ptr scan size was already explored
+	key := s.key(offset)
+	if mptr, ok := s.memo[key]; ok && mptr <= ptr {
+		return
+	}
+	s.memo[key] = ptr
+	// go through all classes in order
+	for i, class := range s.classes {
+		if s.counts[i] == 0 {
+			continue
+		}
+		// place next field of the class
+		f := class[len(class)-s.counts[i]]
+		foffset, fptr := s.place(f, offset, ptr)
+		if f.Ptr > 0 {
+			s.rptrs--
+		}
+		s.counts[i]--
+		s.rsize -= f.Size
+		s.order = append(s.order, i)
+		s.dfs(foffset+f.Size, fptr)
+		// revert the field placement
+		s.order = s.order[:len(s.order)-1]
+		s.rsize += f.Size
+		s.counts[i]++
+		if f.Ptr > 0 {
+			s.rptrs++
+		}
+	}
+}
+
+// complete checks if all fields are placed
+func (s *search) complete() bool {
+	for _, count := range s.counts {
+		if count > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// key builds memo key from
+// the current search state
+func (s *search) key(offset int64) string {
+	var buf strings.Builder
+	buf.WriteString(strconv.FormatInt(offset, 10))
+	for _, count := range s.counts {
+		buf.WriteByte(':')
+		buf.WriteString(strconv.Itoa(count))
+	}
+	return buf.String()
+}
+
+// fields returns the best found fields layout
+func (s *search) fields() []gopium.Field {
+	// restore fields from classes order
+	var fields []gopium.Field
+	counts := make([]int, len(s.classes))
+	for _, i := range s.best {
+		fields = append(fields, collections.CopyField(s.classes[i][counts[i]]))
+		counts[i]++
+	}
+	return fields
+}
@@ -0,0 +1,378 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestOptimal(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		optimal optimal
+		ctx     context.Context
+		o       gopium.Struct
+		r       gopium.Struct
+		err     error
+	}{
+		"empty struct should be applied to empty struct": {
+			optimal: pckopt,
+			ctx:     context.Background(),
+		},
+		"non empty struct should be applied to itself": {
+			optimal: pckopt,
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to itself on canceled context": {
+			optimal: pckopt,
+			ctx:     cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"struct with natural aligns should be applied to pack sorted struct": {
+			optimal: pckopt,
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   8,
+					},
+					{
+						Name:  "test3",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test4",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   8,
+					},
+					{
+						Name:  "test3",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test1",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test4",
+						Type:  "bool",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"struct with unnatural aligns should be applied to smaller than pack struct": {
+			optimal: pckopt,
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  3,
+						Align: 2,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  9,
+						Align: 4,
+						Ptr:   2,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  8,
+						Align: 8,
+						Ptr:   5,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  2,
+						Align: 8,
+						Ptr:   2,
+					},
+					{
+						Name:  "test5",
+						Type:  "test-5",
+						Size:  10,
+						Align: 8,
+						Ptr:   4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  2,
+						Align: 8,
+						Ptr:   2,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  9,
+						Align: 4,
+						Ptr:   2,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  8,
+						Align: 8,
+						Ptr:   5,
+					},
+					{
+						Name:  "test5",
+						Type:  "test-5",
+						Size:  10,
+						Align: 8,
+						Ptr:   4,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  3,
+						Align: 2,
+					},
+				},
+			},
+		},
+		"struct with unnatural aligns should be applied to pack struct on exhausted budget": {
+			optimal: pckoptb.Budget(0),
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  3,
+						Align: 2,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  9,
+						Align: 4,
+						Ptr:   2,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  8,
+						Align: 8,
+						Ptr:   5,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  2,
+						Align: 8,
+						Ptr:   2,
+					},
+					{
+						Name:  "test5",
+						Type:  "test-5",
+						Size:  10,
+						Align: 8,
+						Ptr:   4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  2,
+						Align: 8,
+						Ptr:   2,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  8,
+						Align: 8,
+						Ptr:   5,
+					},
+					{
+						Name:  "test5",
+						Type:  "test-5",
+						Size:  10,
+						Align: 8,
+						Ptr:   4,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  9,
+						Align: 4,
+						Ptr:   2,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  3,
+						Align: 2,
+					},
+				},
+			},
+		},
+		"struct with unnatural aligns should be applied to minimal ptr scan struct": {
+			optimal: pckopt,
+			ctx:     context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  7,
+						Align: 2,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  11,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  4,
+						Align: 2,
+						Ptr:   3,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  10,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  4,
+						Align: 2,
+						Ptr:   3,
+					},
+					{
+						Name:  "test4",
+						Type:  "test-4",
+						Size:  10,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  11,
+						Align: 4,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  7,
+						Align: 2,
+					},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.optimal.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
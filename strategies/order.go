@@ -0,0 +1,84 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of order presets
+var (
+	ordrec  = order{restore: false}
+	ordrest = order{restore: true}
+)
+
+// order defines strategy implementation
+// that either records current fields order
+// in `gopium:"order:N"` tag markers,
+// already recorded markers are never overwritten,
+// or restores fields order recorded in markers
+// and removes markers afterwards,
+// fields without markers (e.g. pads)
+// follow the field they currently follow
+type order struct {
+	restore bool `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 1 bytes; struct align: 1 bytes; struct aligned size: 1 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Apply order implementation
+func (stg order) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	if !stg.restore {
+		// record fields indexes
+		// for fields without markers
+		for i := range r.Fields {
+			f := &r.Fields[i]
+			if _, ok := collections.Marker(f.Tag, collections.MarkOrder); ok {
+				continue
+			}
+			f.Tag = collections.Remark(f.Tag, collections.MarkOrder, fmt.Sprintf("%s:%d", collections.MarkOrder, i))
+		}
+		return r, ctx.Err()
+	}
+	// in case there are no fields
+	// just return result back
+	if len(r.Fields) == 0 {
+		return r, ctx.Err()
+	}
+	// collect fields sort keys,
+	// fields without markers
+	// inherit previous field key
+	keys := make([]int, 0, len(r.Fields))
+	prev := -1
+	for i := range r.Fields {
+		f := &r.Fields[i]
+		if val, ok := collections.Marker(f.Tag, collections.MarkOrder); ok {
+			idx, err := strconv.Atoi(val)
+			if err != nil || idx < 0 {
+				return o, fmt.Errorf("field %q in struct %q has invalid order marker %q", f.Name, r.Name, val)
+			}
+			prev = idx
+			f.Tag = collections.Remark(f.Tag, collections.MarkOrder, "")
+		}
+		keys = append(keys, prev)
+	}
+	// sort fields indexes by keys
+	// and rearrange fields accordingly
+	idxs := make([]int, len(r.Fields))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	sort.SliceStable(idxs, func(i, j int) bool {
+		return keys[idxs[i]] < keys[idxs[j]]
+	})
+	fields := make([]gopium.Field, 0, len(r.Fields))
+	for _, i := range idxs {
+		fields = append(fields, r.Fields[i])
+	}
+	r.Fields = fields
+	return r, ctx.Err()
+}
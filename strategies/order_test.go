@@ -0,0 +1,204 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestOrder(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		order order
+		ctx   context.Context
+		o     gopium.Struct
+		r     gopium.Struct
+		err   error
+	}{
+		"empty struct should be applied to empty struct on record": {
+			order: ordrec,
+			ctx:   context.Background(),
+		},
+		"empty struct should be applied to empty struct on restore": {
+			order: ordrest,
+			ctx:   context.Background(),
+		},
+		"non empty struct should be applied to expected result on record": {
+			order: ordrec,
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+					},
+					{
+						Name: "b",
+						Tag:  `json:"b"`,
+					},
+					{
+						Name: "c",
+						Tag:  `gopium:"cold;group:def;memory_pack"`,
+					},
+					{
+						Name: "d",
+						Tag:  `gopium:"order:0"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Tag:  `gopium:"order:0"`,
+					},
+					{
+						Name: "b",
+						Tag:  `json:"b" gopium:"order:1"`,
+					},
+					{
+						Name: "c",
+						Tag:  `gopium:"order:2;cold;group:def;memory_pack"`,
+					},
+					{
+						Name: "d",
+						Tag:  `gopium:"order:0"`,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to expected result on restore": {
+			order: ordrest,
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "_",
+						Size: 4,
+					},
+					{
+						Name: "c",
+						Tag:  `gopium:"order:2;cold;group:def;memory_pack"`,
+					},
+					{
+						Name: "_",
+						Size: 8,
+					},
+					{
+						Name: "b",
+						Tag:  `json:"b" gopium:"order:1" xml:"b"`,
+					},
+					{
+						Name: "a",
+						Tag:  `gopium:"order:0"`,
+					},
+					{
+						Name: "flags",
+						Tag:  `gopium:"bitset:e,f"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "_",
+						Size: 4,
+					},
+					{
+						Name: "a",
+					},
+					{
+						Name: "flags",
+						Tag:  `gopium:"bitset:e,f"`,
+					},
+					{
+						Name: "b",
+						Tag:  `json:"b" xml:"b"`,
+					},
+					{
+						Name: "c",
+						Tag:  `gopium:"cold;group:def;memory_pack"`,
+					},
+					{
+						Name: "_",
+						Size: 8,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to expected result on restore with invalid marker": {
+			order: ordrest,
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Tag:  `gopium:"order:test"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Tag:  `gopium:"order:test"`,
+					},
+				},
+			},
+			err: errors.New(`field "a" in struct "test" has invalid order marker "test"`),
+		},
+		"non empty struct should be applied to expected result on canceled context": {
+			order: ordrest,
+			ctx:   cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "b",
+						Tag:  `gopium:"order:1"`,
+					},
+					{
+						Name: "a",
+						Tag:  `gopium:"order:0"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+					},
+					{
+						Name: "b",
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.order.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package strategies
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// pins helps to keep fields marked by
+// `gopium:"pin"`, `gopium:"pin:first"`
+// or `gopium:"pin:index=N"` tag markers
+// at their positions inside strategy result,
+// where positions are counted among non pad fields
+// and `pin` marker keeps field position and offset
+// inside strategy original structure;
+// other fields are rearranged around pinned fields
+// in strategy result order and pads are kept
+// in front of fields they precede,
+// `pin` marked fields offsets are restored by
+// padding fields in front of them,
+// pinned field removal, pins conflict or
+// offset that can't be restored are reported as error
+func pins(o gopium.Struct, r gopium.Struct) (gopium.Struct, error) {
+	// collect pinned fields positions
+	// from original structure
+	pos := make(map[string]int)
+	taken := make(map[int]string)
+	offsets := make(map[string]bool)
+	var names []string
+	var idx int
+	for _, f := range o.Fields {
+		if f.Name == "_" {
+			continue
+		}
+		if val, ok := collections.Marker(f.Tag, collections.MarkPin); ok {
+			p := idx
+			switch {
+			case val == "":
+				offsets[f.Name] = true
+			case val == "first":
+				p = 0
+			case strings.HasPrefix(val, "index="):
+				n, err := strconv.Atoi(strings.TrimPrefix(val, "index="))
+				if err != nil || n < 0 {
+					return o, fmt.Errorf("field %q in struct %q has invalid pin marker %q", f.Name, o.Name, val)
+				}
+				p = n
+			default:
+				return o, fmt.Errorf("field %q in struct %q has invalid pin marker %q", f.Name, o.Name, val)
+			}
+			if name, ok := taken[p]; ok {
+				return o, fmt.Errorf("fields %q and %q in struct %q are pinned to the same position %d", name, f.Name, o.Name, p)
+			}
+			pos[f.Name], taken[p] = p, f.Name
+			names = append(names, f.Name)
+		}
+		idx++
+	}
+	// in case there are no pinned fields
+	// just return result back
+	if len(pos) == 0 {
+		return r, nil
+	}
+	// split result fields to pinned
+	// and free fields with their front pads
+	var free [][]gopium.Field
+	var tail []gopium.Field
+	pinned := make(map[int][]gopium.Field, len(pos))
+	for _, f := range r.Fields {
+		tail = append(tail, f)
+		if f.Name == "_" {
+			continue
+		}
+		if p, ok := pos[f.Name]; ok {
+			pinned[p] = tail
+		} else {
+			free = append(free, tail)
+		}
+		tail = nil
+	}
+	n := len(free) + len(pinned)
+	for _, name := range names {
+		p := pos[name]
+		if _, ok := pinned[p]; !ok {
+			return o, fmt.Errorf("pinned field %q in struct %q can't be removed by strategy", name, o.Name)
+		}
+		if p >= n {
+			return o, fmt.Errorf("pinned field %q in struct %q can't be placed at position %d of %d fields", name, o.Name, p, n)
+		}
+	}
+	// place pinned fields at their positions
+	// and fill the rest with free fields
+	fields := make([]gopium.Field, 0, len(r.Fields))
+	for i := 0; i < n; i++ {
+		if pfields, ok := pinned[i]; ok {
+			fields = append(fields, pfields...)
+			continue
+		}
+		fields = append(fields, free[0]...)
+		free = free[1:]
+	}
+	r.Fields = append(fields, tail...)
+	// keep `pin` marked fields at their
+	// original offsets by padding
+	// fields in front of them
+	for _, name := range names {
+		if !offsets[name] {
+			continue
+		}
+		_, _, ooff := pinoffset(o, name)
+		i, end, roff := pinoffset(r, name)
+		switch {
+		case roff == ooff:
+		case end < ooff:
+			pad := collections.PadField(ooff - end)
+			r.Fields = append(r.Fields[:i:i], append([]gopium.Field{pad}, r.Fields[i:]...)...)
+		default:
+			return o, fmt.Errorf("pinned field %q in struct %q can't be kept at offset %d", name, o.Name, ooff)
+		}
+	}
+	return r, nil
+}
+
+// pinoffset returns structure field index,
+// its offset and end offset of fields
+// in front of it without alignment padding
+func pinoffset(st gopium.Struct, name string) (int, int64, int64) {
+	idx, i := -1, 0
+	var cur, end, off int64
+	collections.WalkStruct(st, 0, func(pad int64, fields ...gopium.Field) {
+		for _, f := range fields {
+			if idx < 0 && f.Name == name {
+				idx, end, off = i, cur, cur+pad
+			}
+			// fields with invalid align
+			// don't affect offsets
+			if f.Align > 0 {
+				cur += pad + f.Size
+			}
+			i++
+		}
+	})
+	return idx, end, off
+}
+
+// unpin removes pinned fields markers
+// from structure copy and returns
+// the copy with removed markers tokens
+func unpin(o gopium.Struct) (gopium.Struct, map[string]string) {
+	r := collections.CopyStruct(o)
+	tokens := make(map[string]string)
+	for i := range r.Fields {
+		f := &r.Fields[i]
+		if val, ok := collections.Marker(f.Tag, collections.MarkPin); ok {
+			tokens[f.Name] = collections.MarkPin
+			if val != "" {
+				tokens[f.Name] += ":" + val
+			}
+			f.Tag = collections.Remark(f.Tag, collections.MarkPin, "")
+		}
+	}
+	return r, tokens
+}
+
+// repin puts back removed pinned
+// fields markers tokens to structure
+func repin(r gopium.Struct, tokens map[string]string) gopium.Struct {
+	for i := range r.Fields {
+		f := &r.Fields[i]
+		if token, ok := tokens[f.Name]; ok {
+			f.Tag = collections.Remark(f.Tag, collections.MarkPin, token)
+		}
+	}
+	return r
+}
@@ -0,0 +1,478 @@
+package strategies
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestPins(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		o   gopium.Struct
+		r   gopium.Struct
+		pr  gopium.Struct
+		err error
+	}{
+		"empty structs should be pinned to empty struct": {},
+		"structs without pinned fields should be pinned to result struct": {
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+					},
+					{
+						Name: "b",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "b",
+					},
+					{
+						Name: "a",
+					},
+				},
+			},
+			pr: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "b",
+					},
+					{
+						Name: "a",
+					},
+				},
+			},
+		},
+		"structs with pinned fields should be pinned to expected struct": {
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+					},
+					{
+						Name: "_",
+					},
+					{
+						Name: "b",
+						Tag:  `gopium:"pin"`,
+					},
+					{
+						Name: "c",
+					},
+					{
+						Name: "d",
+						Tag:  `gopium:"pin:index=3;memory_pack"`,
+					},
+					{
+						Name: "e",
+						Tag:  `gopium:"pin:first"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "e",
+						Tag:  `gopium:"pin:first"`,
+					},
+					{
+						Name: "d",
+						Tag:  `gopium:"pin:index=3;memory_pack"`,
+					},
+					{
+						Name: "c",
+					},
+					{
+						Name: "_",
+						Size: 4,
+					},
+					{
+						Name: "b",
+						Tag:  `gopium:"pin"`,
+					},
+					{
+						Name: "a",
+					},
+					{
+						Name: "_",
+						Size: 8,
+					},
+				},
+			},
+			pr: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "e",
+						Tag:  `gopium:"pin:first"`,
+					},
+					{
+						Name: "_",
+						Size: 4,
+					},
+					{
+						Name: "b",
+						Tag:  `gopium:"pin"`,
+					},
+					{
+						Name: "c",
+					},
+					{
+						Name: "d",
+						Tag:  `gopium:"pin:index=3;memory_pack"`,
+					},
+					{
+						Name: "a",
+					},
+					{
+						Name: "_",
+						Size: 8,
+					},
+				},
+			},
+		},
+		"structs with invalid pin marker should be pinned to expected error": {
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Tag:  `gopium:"pin:last"`,
+					},
+				},
+			},
+			pr: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Tag:  `gopium:"pin:last"`,
+					},
+				},
+			},
+			err: errors.New(`field "a" in struct "test" has invalid pin marker "last"`),
+		},
+		"structs with invalid pin index marker should be pinned to expected error": {
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Tag:  `gopium:"pin:index=-1"`,
+					},
+				},
+			},
+			pr: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Tag:  `gopium:"pin:index=-1"`,
+					},
+				},
+			},
+			err: errors.New(`field "a" in struct "test" has invalid pin marker "index=-1"`),
+		},
+		"structs with conflicting pin markers should be pinned to expected error": {
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Tag:  `gopium:"pin"`,
+					},
+					{
+						Name: "b",
+						Tag:  `gopium:"pin:index=0"`,
+					},
+				},
+			},
+			pr: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+						Tag:  `gopium:"pin"`,
+					},
+					{
+						Name: "b",
+						Tag:  `gopium:"pin:index=0"`,
+					},
+				},
+			},
+			err: errors.New(`fields "a" and "b" in struct "test" are pinned to the same position 0`),
+		},
+		"structs with removed pinned field should be pinned to expected error": {
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+					},
+					{
+						Name: "b",
+						Tag:  `gopium:"pin;cold"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+					},
+				},
+			},
+			pr: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+					},
+					{
+						Name: "b",
+						Tag:  `gopium:"pin;cold"`,
+					},
+				},
+			},
+			err: errors.New(`pinned field "b" in struct "test" can't be removed by strategy`),
+		},
+		"structs with out of range pinned field should be pinned to expected error": {
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+					},
+					{
+						Name: "b",
+						Tag:  `gopium:"pin:index=2"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "b",
+						Tag:  `gopium:"pin:index=2"`,
+					},
+					{
+						Name: "a",
+					},
+				},
+			},
+			pr: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "a",
+					},
+					{
+						Name: "b",
+						Tag:  `gopium:"pin:index=2"`,
+					},
+				},
+			},
+			err: errors.New(`pinned field "b" in struct "test" can't be placed at position 2 of 2 fields`),
+		},
+		"structs with moved pinned field offset should be pinned to expected padded struct": {
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "_",
+						Type:  "[15]byte",
+						Size:  15,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Tag:   `gopium:"pin"`,
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Tag:   `gopium:"pin"`,
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			pr: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "_",
+						Type:  "[15]byte",
+						Size:  15,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Tag:   `gopium:"pin"`,
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"structs with unrestorable pinned field offset should be pinned to expected error": {
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Tag:   `gopium:"pin"`,
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "c",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "c",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "b",
+						Tag:   `gopium:"pin"`,
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "a",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			pr: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "b",
+						Tag:   `gopium:"pin"`,
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "c",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			err: errors.New(`pinned field "b" in struct "test" can't be kept at offset 1`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			pr, err := pins(tcase.o, tcase.r)
+			// check
+			if !reflect.DeepEqual(pr, tcase.pr) {
+				t.Errorf("actual %v doesn't equal to expected %v", pr, tcase.pr)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestUnpinRepin(t *testing.T) {
+	// prepare
+	o := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name: "a",
+				Tag:  `json:"a" gopium:"pin:first;memory_pack"`,
+			},
+			{
+				Name: "b",
+				Tag:  `gopium:"pin"`,
+			},
+			{
+				Name: "c",
+			},
+		},
+	}
+	u := gopium.Struct{
+		Name: "test",
+		Fields: []gopium.Field{
+			{
+				Name: "a",
+				Tag:  `json:"a" gopium:"memory_pack"`,
+			},
+			{
+				Name: "b",
+			},
+			{
+				Name: "c",
+			},
+		},
+	}
+	// exec
+	r, tokens := unpin(o)
+	// check
+	if !reflect.DeepEqual(r, u) {
+		t.Errorf("actual %v doesn't equal to expected %v", r, u)
+	}
+	r = repin(r, tokens)
+	if !reflect.DeepEqual(r, o) {
+		t.Errorf("actual %v doesn't equal to expected %v", r, o)
+	}
+}
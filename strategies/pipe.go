@@ -33,9 +33,59 @@ func (stgs pipe) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, err
 		if err != nil {
 			return r, err
 		}
+		// keep pinned fields
+		// at their positions
+		if tmp, err = pins(r, tmp); err != nil {
+			return r, err
+		}
 		// copy result back to
 		// result structure
 		r = tmp
 	}
 	return r, ctx.Err()
 }
+
+// Nested pipe implementation
+func (stgs pipe) Nested() bool {
+	// pipe is nested if any
+	// of inner strategies is nested
+	for _, stg := range stgs {
+		if nstg, ok := stg.(gopium.NestedStrategy); ok && nstg.Nested() {
+			return true
+		}
+	}
+	return false
+}
+
+// Usages pipe implementation
+func (stgs pipe) Usages() bool {
+	// pipe requires usages if any
+	// of inner strategies requires them
+	for _, stg := range stgs {
+		if ustg, ok := stg.(gopium.UsageStrategy); ok && ustg.Usages() {
+			return true
+		}
+	}
+	return false
+}
+
+// Archs pipe implementation
+func (stgs pipe) Archs() []string {
+	// pipe targets are unique
+	// targets of all inner strategies
+	var targets []string
+	used := make(map[string]bool)
+	for _, stg := range stgs {
+		astg, ok := stg.(gopium.ArchStrategy)
+		if !ok {
+			continue
+		}
+		for _, target := range astg.Archs() {
+			if !used[target] {
+				used[target] = true
+				targets = append(targets, target)
+			}
+		}
+	}
+	return targets
+}
@@ -115,6 +115,58 @@ func TestPipe(t *testing.T) {
 			},
 			err: context.Canceled,
 		},
+		"non empty struct should be applied accordingly to pipe with pinned fields": {
+			pipe: pipe([]gopium.Strategy{pck}),
+			ctx:  context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "byte",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"pin:first"`,
+					},
+					{
+						Name:  "b",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "c",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "byte",
+						Size:  1,
+						Align: 1,
+						Tag:   `gopium:"pin:first"`,
+					},
+					{
+						Name:  "c",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "b",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+		},
 		"non empty struct should be applied to expected result on pipe error": {
 			pipe: pipe([]gopium.Strategy{
 				fnotecom,
@@ -158,3 +210,103 @@ func TestPipe(t *testing.T) {
 		})
 	}
 }
+
+func TestPipeNested(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		pipe   pipe
+		nested bool
+	}{
+		"empty pipe should not be nested": {},
+		"pipe without nested strategies should not be nested": {
+			pipe: pipe([]gopium.Strategy{pck, fnotecom}),
+		},
+		"pipe with nested strategy should be nested": {
+			pipe:   pipe([]gopium.Strategy{pckdeep, fnotecom}),
+			nested: true,
+		},
+		"pipe with nested inner pipe should be nested": {
+			pipe:   pipe([]gopium.Strategy{fnotecom, pipe([]gopium.Strategy{pckdeep})}),
+			nested: true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			nested := tcase.pipe.Nested()
+			// check
+			if !reflect.DeepEqual(nested, tcase.nested) {
+				t.Errorf("actual %v doesn't equal to expected %v", nested, tcase.nested)
+			}
+		})
+	}
+}
+
+func TestPipeUsages(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		pipe   pipe
+		usages bool
+	}{
+		"empty pipe should not require usages": {},
+		"pipe without usage strategies should not require usages": {
+			pipe: pipe([]gopium.Strategy{pck, fnotecom}),
+		},
+		"pipe with usage strategy should require usages": {
+			pipe:   pipe([]gopium.Strategy{atomic64, fnotecom}),
+			usages: true,
+		},
+		"pipe with usage inner pipe should require usages": {
+			pipe:   pipe([]gopium.Strategy{fnotecom, pipe([]gopium.Strategy{heatl1})}),
+			usages: true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			usages := tcase.pipe.Usages()
+			// check
+			if !reflect.DeepEqual(usages, tcase.usages) {
+				t.Errorf("actual %v doesn't equal to expected %v", usages, tcase.usages)
+			}
+		})
+	}
+}
+
+func TestPipeArchs(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		pipe  pipe
+		archs []string
+	}{
+		"empty pipe should not have archs": {},
+		"pipe without arch strategies should not have archs": {
+			pipe: pipe([]gopium.Strategy{pck, fnotecom}),
+		},
+		"pipe with arch strategies should have unique archs": {
+			pipe: pipe([]gopium.Strategy{
+				pckarchsum.Targets("gc/amd64", "gc/386"),
+				fnotecom,
+				pckarchmax.Targets("gc/386", "gc/arm"),
+			}),
+			archs: []string{"gc/amd64", "gc/386", "gc/arm"},
+		},
+		"pipe with arch inner pipe should have archs": {
+			pipe: pipe([]gopium.Strategy{
+				fnotecom,
+				pipe([]gopium.Strategy{pckarchsum.Targets("gc/arm")}),
+			}),
+			archs: []string{"gc/arm"},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			archs := tcase.pipe.Archs()
+			// check
+			if !reflect.DeepEqual(archs, tcase.archs) {
+				t.Errorf("actual %v doesn't equal to expected %v", archs, tcase.archs)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package strategies
+
+import (
+	"context"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of pscan presets
+var (
+	pscanmin = pscan{budget: 1 << 16}
+)
+
+// pscan defines strategy implementation
+// that rearranges structure fields
+// to obtain minimal ptr scan size
+// by placing pointerful fields first,
+// it never increases struct aligned size
+// achieved by pack strategy
+type pscan struct {
+	budget uint `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 8 bytes; struct align: 8 bytes; struct aligned size: 8 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Budget erich pscan strategy with custom search budget
+func (stg pscan) Budget(budget uint) pscan {
+	stg.budget = budget
+	return stg
+}
+
+// Apply pscan implementation
+func (stg pscan) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// start from greedy pack layout
+	// which defines size limit
+	r, err := pck.Apply(ctx, o)
+	// in case of any error
+	// just return error back
+	if err != nil {
+		return r, err
+	}
+	// run bounded layout search in ptr first mode
+	// and use its best found layout
+	s := newsearch(r.Fields, stg.budget, true)
+	s.dfs(0, 0)
+	r.Fields = s.fields()
+	return r, ctx.Err()
+}
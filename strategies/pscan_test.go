@@ -0,0 +1,230 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestPscan(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		pscan pscan
+		ctx   context.Context
+		o     gopium.Struct
+		r     gopium.Struct
+		err   error
+	}{
+		"empty struct should be applied to empty struct": {
+			pscan: pscanmin,
+			ctx:   context.Background(),
+		},
+		"non empty struct should be applied to itself": {
+			pscan: pscanmin,
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to itself on canceled context": {
+			pscan: pscanmin,
+			ctx:   cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"struct with small pointerful field should be applied to pointerful first struct": {
+			pscan: pscanmin,
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  4,
+						Align: 4,
+						Ptr:   4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  4,
+						Align: 4,
+						Ptr:   4,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"struct with small pointerful field should be applied to pack struct on exhausted budget": {
+			pscan: pscanmin.Budget(0),
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  4,
+						Align: 4,
+						Ptr:   4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "test-3",
+						Size:  4,
+						Align: 4,
+						Ptr:   4,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"struct with pointerful first inflation should be applied to pack struct": {
+			pscan: pscanmin,
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+						Ptr:   1,
+					},
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  3,
+						Align: 2,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "test-2",
+						Size:  3,
+						Align: 2,
+					},
+					{
+						Name:  "test1",
+						Type:  "test-1",
+						Size:  1,
+						Align: 1,
+						Ptr:   1,
+					},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.pscan.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
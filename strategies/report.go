@@ -0,0 +1,88 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of report presets
+var (
+	streport = report{}
+)
+
+// report defines strategy implementation
+// that adds struct waste report doc annotation
+// with total padding bytes, number of padding holes,
+// cpu cache line #1 number spanned by structure,
+// structure size achievable by memory pack
+// and go allocator size class of structure
+type report struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Curator erich report strategy with curator instance
+func (stg report) Curator(curator gopium.Curator) report {
+	stg.curator = curator
+	return stg
+}
+
+// Apply report implementation
+func (stg report) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// collect padding bytes and holes,
+	// explicit pad fields are treated
+	// as paddings as well
+	var alsize, padding, holes int64
+	hole := false
+	collections.WalkStruct(r, 0, func(pad int64, fields ...gopium.Field) {
+		alsize += pad
+		if pad > 0 {
+			padding += pad
+			if !hole {
+				holes++
+			}
+			hole = true
+		}
+		for _, f := range fields {
+			alsize += f.Size
+			if f.Name == "_" && f.Size > 0 {
+				padding += f.Size
+				if !hole {
+					holes++
+				}
+				hole = true
+				continue
+			}
+			if f.Size > 0 {
+				hole = false
+			}
+		}
+	})
+	// calculate memory pack size
+	// without explicit pad fields,
+	// we could skip errors here
+	// as only context errors are possible
+	packed, _ := fpad.Apply(ctx, r)
+	packed, _ = pck.Apply(ctx, packed)
+	pksize, _, _ := collections.SizeAlignPtr(packed)
+	// note structure with report doc
+	var lines string
+	if cachel := stg.curator.SysCache(1); cachel > 0 {
+		lines = fmt.Sprintf(" struct cpu l1 lines: %d;", (alsize+cachel-1)/cachel)
+	}
+	note := fmt.Sprintf(
+		"// struct padding: %d bytes; struct holes: %d;%s struct packed size: %d bytes; struct size class: %d bytes; - %s",
+		padding,
+		holes,
+		lines,
+		pksize,
+		collections.SizeClass(alsize),
+		gopium.STAMP,
+	)
+	r.Doc = append(r.Doc, note)
+	return r, ctx.Err()
+}
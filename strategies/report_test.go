@@ -0,0 +1,162 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestReport(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		c   gopium.Curator
+		ctx context.Context
+		o   gopium.Struct
+		r   gopium.Struct
+		err error
+	}{
+		"empty struct should be applied to expected report struct": {
+			c:   mocks.Maven{SCache: []int64{32}},
+			ctx: context.Background(),
+			r: gopium.Struct{
+				Doc: []string{"// struct padding: 0 bytes; struct holes: 0; struct cpu l1 lines: 0; struct packed size: 0 bytes; struct size class: 0 bytes; - 🌺 gopium @1pkg"},
+			},
+		},
+		"non empty struct should be applied to expected report struct": {
+			c:   mocks.Maven{SCache: []int64{32}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Doc:  []string{"// test"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Doc: []string{
+					"// test",
+					"// struct padding: 14 bytes; struct holes: 2; struct cpu l1 lines: 1; struct packed size: 16 bytes; struct size class: 24 bytes; - 🌺 gopium @1pkg",
+				},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"struct with explicit pads should be applied to expected report struct": {
+			c:   mocks.Maven{SCache: []int64{16}},
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					collections.PadField(2),
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+					collections.PadField(16),
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Doc:  []string{"// struct padding: 20 bytes; struct holes: 2; struct cpu l1 lines: 2; struct packed size: 16 bytes; struct size class: 32 bytes; - 🌺 gopium @1pkg"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					collections.PadField(2),
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+					collections.PadField(16),
+				},
+			},
+		},
+		"non empty struct should be applied to expected report struct without cache lines on canceled context": {
+			c:   mocks.Maven{},
+			ctx: cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  65,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Doc:  []string{"// struct padding: 0 bytes; struct holes: 0; struct packed size: 65 bytes; struct size class: 80 bytes; - 🌺 gopium @1pkg"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  65,
+						Align: 1,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			report := streport.Curator(tcase.c)
+			// exec
+			r, err := report.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of sclass presets
+var (
+	sclassfit = sclass{}
+	sclasspad = sclass{pad: true}
+)
+
+// sclass defines strategy implementation
+// that fits structure into go allocator size class,
+// it tries to remove explicit paddings and to pack
+// structure fields to drop structure into lower
+// size class and keeps structure unchanged otherwise,
+// optionally it pads structure up to its size class
+// boundary so free tail bytes become visible,
+// structure size class is noted in structure comment
+type sclass struct {
+	pad bool `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 1 bytes; struct align: 1 bytes; struct aligned size: 1 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+
+// Apply sclass implementation
+func (stg sclass) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	size, align, _ := collections.SizeAlignPtr(r)
+	class := collections.SizeClass(size)
+	// collect candidates: structure
+	// without explicit paddings
+	// and packed structure without them,
+	// we could skip errors here
+	// as only context errors are possible
+	filtered, _ := fpad.Apply(ctx, r)
+	packed, _ := pck.Apply(ctx, filtered)
+	// pick the first candidate
+	// with the lowest size class
+	for _, st := range []gopium.Struct{filtered, packed} {
+		stsize, stalign, _ := collections.SizeAlignPtr(st)
+		if stclass := collections.SizeClass(stsize); stclass < class {
+			r, size, align, class = st, stsize, stalign, stclass
+		}
+	}
+	// pad structure up to size class boundary
+	// only if it doesn't break structure alignment
+	if tail := class - size; stg.pad && tail > 0 && collections.Align(size+tail, align) == class {
+		r.Fields = append(r.Fields, collections.PadField(tail))
+		size = class
+	}
+	// note structure with size class comment
+	note := fmt.Sprintf(
+		"// struct size: %d bytes; struct size class: %d bytes; struct size class tail: %d bytes; - %s",
+		size,
+		class,
+		class-size,
+		gopium.STAMP,
+	)
+	r.Comment = append(r.Comment, note)
+	return r, ctx.Err()
+}
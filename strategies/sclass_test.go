@@ -0,0 +1,223 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestSclass(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		sclass sclass
+		ctx    context.Context
+		o      gopium.Struct
+		r      gopium.Struct
+		err    error
+	}{
+		"empty struct should be applied to expected noted struct": {
+			sclass: sclassfit,
+			ctx:    context.Background(),
+			r: gopium.Struct{
+				Comment: []string{"// struct size: 0 bytes; struct size class: 0 bytes; struct size class tail: 0 bytes; - 🌺 gopium @1pkg"},
+			},
+		},
+		"non empty struct should be applied to expected packed struct": {
+			sclass: sclassfit,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"// struct size: 16 bytes; struct size class: 16 bytes; struct size class tail: 0 bytes; - 🌺 gopium @1pkg"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test3",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+		},
+		"non empty struct with pads should be applied to expected struct without pads": {
+			sclass: sclassfit,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					collections.PadField(12),
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"// struct size: 16 bytes; struct size class: 16 bytes; struct size class tail: 0 bytes; - 🌺 gopium @1pkg"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"non empty struct in the lowest size class should be applied to itself": {
+			sclass: sclassfit,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"// struct size: 16 bytes; struct size class: 16 bytes; struct size class tail: 0 bytes; - 🌺 gopium @1pkg"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  1,
+						Align: 1,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to expected padded struct in pad mode": {
+			sclass: sclasspad,
+			ctx:    context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  32,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"// struct size: 48 bytes; struct size class: 48 bytes; struct size class tail: 0 bytes; - 🌺 gopium @1pkg"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  32,
+						Align: 8,
+					},
+					{
+						Name:  "test2",
+						Size:  1,
+						Align: 1,
+					},
+					collections.PadField(8),
+				},
+			},
+		},
+		"non empty struct should be applied to expected noted struct on canceled context": {
+			sclass: sclassfit,
+			ctx:    cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  65,
+						Align: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name:    "test",
+				Comment: []string{"// struct size: 65 bytes; struct size class: 80 bytes; struct size class tail: 15 bytes; - 🌺 gopium @1pkg"},
+				Fields: []gopium.Field{
+					{
+						Name:  "test",
+						Size:  65,
+						Align: 1,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.sclass.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
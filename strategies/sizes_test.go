@@ -0,0 +1,174 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+func TestSizes(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		sizes sizes
+		ctx   context.Context
+		o     gopium.Struct
+		r     gopium.Struct
+		err   error
+	}{
+		"empty struct should be applied to empty struct": {
+			sizes: sizesasc,
+			ctx:   context.Background(),
+		},
+		"non empty struct should be applied to itself": {
+			sizes: sizesasc,
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to itself on canceled context": {
+			sizes: sizesdesc,
+			ctx:   cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"mixed size struct should be applied to stable sorted struct asc": {
+			sizes: sizesasc,
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test1",
+						Size: 4,
+					},
+					{
+						Name: "test2",
+						Size: 8,
+					},
+					{
+						Name: "test3",
+						Size: 4,
+					},
+					{
+						Name: "test4",
+						Size: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test4",
+						Size: 1,
+					},
+					{
+						Name: "test1",
+						Size: 4,
+					},
+					{
+						Name: "test3",
+						Size: 4,
+					},
+					{
+						Name: "test2",
+						Size: 8,
+					},
+				},
+			},
+		},
+		"mixed size struct should be applied to stable sorted struct desc": {
+			sizes: sizesdesc,
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test1",
+						Size: 4,
+					},
+					{
+						Name: "test2",
+						Size: 8,
+					},
+					{
+						Name: "test3",
+						Size: 4,
+					},
+					{
+						Name: "test4",
+						Size: 1,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test2",
+						Size: 8,
+					},
+					{
+						Name: "test1",
+						Size: 4,
+					},
+					{
+						Name: "test3",
+						Size: 4,
+					},
+					{
+						Name: "test4",
+						Size: 1,
+					},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.sizes.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of split presets
+var (
+	splitc = split{}
+)
+
+// split defines strategy implementation
+// that moves all fields marked as cold
+// by `gopium:"cold"` tag marker
+// to generated companion structure
+// which is referenced by new pointer field,
+// structures without hot fields are not split.
+// note: companion structure generation
+// and selectors rewriting are done by ast walkers,
+// companion structure is allocated by rewritten
+// keyed composite literals with cold fields or
+// by generated accessor on first cold field access,
+// ast walkers return error for unkeyed literals
+type split struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Curator erich split strategy with curator instance
+func (stg split) Curator(curator gopium.Curator) split {
+	stg.curator = curator
+	return stg
+}
+
+// Apply split implementation
+func (stg split) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// collect hot and cold fields
+	ctype, cname := collections.Cold(r.Name)
+	hot := make([]gopium.Field, 0, len(r.Fields))
+	var cold []gopium.Field
+	for _, f := range r.Fields {
+		if _, ok := collections.Marker(f.Tag, collections.MarkCold); ok {
+			cold = append(cold, f)
+			continue
+		}
+		hot = append(hot, f)
+	}
+	// in case there is nothing to split
+	// just return result back
+	if len(cold) == 0 || len(hot) == 0 {
+		return r, ctx.Err()
+	}
+	// check that companion pointer
+	// field name is not taken yet
+	for _, f := range hot {
+		if f.Name == cname {
+			return o, fmt.Errorf("field %q in struct %q conflicts with cold companion pointer field", f.Name, r.Name)
+		}
+	}
+	// replace cold fields with
+	// companion structure pointer
+	word := stg.curator.SysWord()
+	r.Fields = append(hot, gopium.Field{
+		Name:  cname,
+		Type:  "*" + ctype,
+		Size:  word,
+		Align: word,
+		Ptr:   word,
+	})
+	return r, ctx.Err()
+}
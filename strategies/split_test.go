@@ -0,0 +1,214 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestSplit(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		split split
+		ctx   context.Context
+		o     gopium.Struct
+		r     gopium.Struct
+		err   error
+	}{
+		"empty struct should be applied to empty struct": {
+			split: splitc.Curator(mocks.Maven{SWord: 8}),
+			ctx:   context.Background(),
+		},
+		"non empty struct without cold fields should be applied to itself": {
+			split: splitc.Curator(mocks.Maven{SWord: 8}),
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+						Tag:  `gopium:"memory_pack"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+						Tag:  `gopium:"memory_pack"`,
+					},
+				},
+			},
+		},
+		"non empty struct with only cold fields should be applied to itself": {
+			split: splitc.Curator(mocks.Maven{SWord: 8}),
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+						Tag:  `gopium:"cold"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+						Tag:  `gopium:"cold"`,
+					},
+				},
+			},
+		},
+		"non empty struct with cold fields should be applied to split struct": {
+			split: splitc.Curator(mocks.Maven{SWord: 8}),
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   8,
+						Tag:   `gopium:"cold;memory_pack"`,
+					},
+					{
+						Name:  "test2",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "test3",
+						Type:  "[]byte",
+						Size:  24,
+						Align: 8,
+						Ptr:   8,
+						Tag:   `json:"test3" gopium:"cold"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "int64",
+						Size:  8,
+						Align: 8,
+					},
+					{
+						Name:  "cold",
+						Type:  "*testCold",
+						Size:  8,
+						Align: 8,
+						Ptr:   8,
+					},
+				},
+			},
+		},
+		"non empty struct with cold fields should be applied to split struct on canceled context": {
+			split: splitc.Curator(mocks.Maven{SWord: 4}),
+			ctx:   cctx,
+			o: gopium.Struct{
+				Name: "Test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+						Tag:   `gopium:"cold"`,
+					},
+					{
+						Name:  "test2",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "Test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test2",
+						Type:  "int32",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "cold",
+						Type:  "*TestCold",
+						Size:  4,
+						Align: 4,
+						Ptr:   4,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"non empty struct with conflicting field should be applied to itself with error": {
+			split: splitc.Curator(mocks.Maven{SWord: 8}),
+			ctx:   context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+						Tag:  `gopium:"cold"`,
+					},
+					{
+						Name: "cold",
+						Type: "bool",
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Type: "test",
+						Tag:  `gopium:"cold"`,
+					},
+					{
+						Name: "cold",
+						Type: "bool",
+					},
+				},
+			},
+			err: errors.New(`field "cold" in struct "test" conflicts with cold companion pointer field`),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			r, err := tcase.split.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
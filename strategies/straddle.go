@@ -0,0 +1,120 @@
+package strategies
+
+import (
+	"context"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of straddle presets
+var (
+	straddlel1 = straddle{line: 1}
+	straddlel2 = straddle{line: 2}
+	straddlel3 = straddle{line: 3}
+	straddleb  = straddle{}
+)
+
+// straddle defines strategy implementation
+// that prevents structure fields from
+// crossing cpu cache line boundaries
+// by moving next fitting fields to fill the line
+// or by adding minimal cpu cache line paddings,
+// fields bigger than cache line or with align
+// not dividing cache line are kept as is
+type straddle struct {
+	curator gopium.Curator `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	line    uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bytes   uint           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 16 bytes; - 🌺 gopium @1pkg
+
+// Bytes erich straddle strategy with custom bytes
+func (stg straddle) Bytes(bytes uint) straddle {
+	stg.bytes = bytes
+	return stg
+}
+
+// Curator erich straddle strategy with curator instance
+func (stg straddle) Curator(curator gopium.Curator) straddle {
+	stg.curator = curator
+	return stg
+}
+
+// Apply straddle implementation
+func (stg straddle) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error) {
+	// copy original structure to result
+	r := collections.CopyStruct(o)
+	// check that struct has fields
+	// and cache line size or bytes are valid
+	if flen, cachel := len(r.Fields), stg.curator.SysCache(stg.line); flen > 0 && (cachel > 0 || stg.bytes > 0) {
+		if stg.line == 0 {
+			cachel = int64(stg.bytes)
+		}
+		// fix first straddling field
+		// until there are no straddling fields left,
+		// as all fields before the fixed one are kept
+		// each field is fixed at most once
+		for {
+			i, end, lend := stg.find(r, cachel)
+			if i < 0 {
+				break
+			}
+			// try to find next field that fits
+			// into current cache line remains
+			// and move it right before straddling field
+			if j := stg.fit(r.Fields[i+1:], end, lend); j >= 0 {
+				f := r.Fields[i+1+j]
+				copy(r.Fields[i+1:], r.Fields[i:i+1+j])
+				r.Fields[i] = f
+				continue
+			}
+			// otherwise pad straddling field
+			// to the next cache line start
+			fields := make([]gopium.Field, 0, len(r.Fields)+1)
+			fields = append(fields, r.Fields[:i]...)
+			fields = append(fields, collections.PadField(lend-end))
+			fields = append(fields, r.Fields[i:]...)
+			r.Fields = fields
+		}
+	}
+	return r, ctx.Err()
+}
+
+// find walks through structure layout
+// and finds first field that straddles cache line,
+// it returns field index, previous field end offset
+// and straddled cache line end offset
+func (stg straddle) find(st gopium.Struct, cachel int64) (int, int64, int64) {
+	idx, end, lend := -1, int64(0), int64(0)
+	var i int
+	var offset int64
+	collections.WalkStruct(st, 0, func(pad int64, fields ...gopium.Field) {
+		for _, f := range fields {
+			start := offset + pad
+			// check that field could fit into cache line
+			// and it crosses cache line boundary
+			fit := f.Size > 0 && f.Size <= cachel && (f.Align == 0 || cachel%f.Align == 0)
+			if idx < 0 && fit && start/cachel != (start+f.Size-1)/cachel {
+				idx, end, lend = i, offset, (start/cachel+1)*cachel
+			}
+			offset = start + f.Size
+			i++
+		}
+	})
+	return idx, end, lend
+}
+
+// fit finds first non empty field that could be placed
+// after provided offset without crossing provided cache line end
+func (stg straddle) fit(fields []gopium.Field, end int64, lend int64) int {
+	for i, f := range fields {
+		start := end
+		if f.Align > 0 {
+			start = collections.Align(end, f.Align)
+		}
+		if f.Size > 0 && start+f.Size <= lend {
+			return i
+		}
+	}
+	return -1
+}
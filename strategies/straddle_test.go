@@ -0,0 +1,300 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestStraddle(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		straddle straddle
+		c        gopium.Curator
+		ctx      context.Context
+		o        gopium.Struct
+		r        gopium.Struct
+		err      error
+	}{
+		"empty struct should be applied to empty struct": {
+			straddle: straddlel1,
+			c:        mocks.Maven{SCache: []int64{16}},
+			ctx:      context.Background(),
+		},
+		"non empty struct should be applied to itself on invalid cache line": {
+			straddle: straddlel2,
+			c:        mocks.Maven{SCache: []int64{16}},
+			ctx:      context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"straddling struct should be applied to struct with moved fitting field": {
+			straddle: straddlel1,
+			c:        mocks.Maven{SCache: []int64{16}},
+			ctx:      context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"straddling struct should be applied to struct with moved fitting field on canceled context": {
+			straddle: straddlel1,
+			c:        mocks.Maven{SCache: []int64{16}},
+			ctx:      cctx,
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+			err: context.Canceled,
+		},
+		"straddling struct should be applied to struct with minimal padding": {
+			straddle: straddlel3,
+			c:        mocks.Maven{SCache: []int64{16, 16, 16}},
+			ctx:      context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  12,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  12,
+						Align: 4,
+					},
+					collections.PadField(4),
+					{
+						Name:  "test2",
+						Size:  8,
+						Align: 4,
+					},
+					{
+						Name:  "test3",
+						Size:  8,
+						Align: 4,
+					},
+				},
+			},
+		},
+		"struct with big field should be applied to itself": {
+			straddle: straddlel1,
+			c:        mocks.Maven{SCache: []int64{16}},
+			ctx:      context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  32,
+						Align: 8,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  4,
+						Align: 4,
+					},
+					{
+						Name:  "test2",
+						Size:  32,
+						Align: 8,
+					},
+				},
+			},
+		},
+		"straddling struct should be applied to struct with minimal padding custom bytes": {
+			straddle: straddleb.Bytes(8),
+			c:        mocks.Maven{},
+			ctx:      context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  6,
+						Align: 2,
+					},
+					{
+						Name:  "test2",
+						Size:  4,
+						Align: 2,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name:  "test1",
+						Size:  6,
+						Align: 2,
+					},
+					collections.PadField(2),
+					{
+						Name:  "test2",
+						Size:  4,
+						Align: 2,
+					},
+				},
+			},
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			straddle := tcase.straddle.Curator(tcase.c)
+			// exec
+			r, err := straddle.Apply(tcase.ctx, tcase.o)
+			// check
+			if !reflect.DeepEqual(r, tcase.r) {
+				t.Errorf("actual %v doesn't equal to expected %v", r, tcase.r)
+			}
+			if !errors.Is(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
@@ -58,6 +58,22 @@ func (stg tag) Apply(ctx context.Context, o gopium.Struct) (gopium.Struct, error
 			group := fmt.Sprintf("%s-%d", gopium.NAME, i+1)
 			gtag = fmt.Sprintf("group:%s;%s", group, stg.tag)
 		}
+		// tag markers should be always preserved
+		// so keep them in front of group tag
+		// and treat markers only tag as empty
+		mtokens, rtokens := collections.Marks(tag)
+		if len(mtokens) > 0 {
+			gtag = strings.Trim(strings.Join(mtokens, ";")+";"+gtag, ";")
+			if len(rtokens) == 0 {
+				f.Tag = strings.Replace(
+					f.Tag,
+					fmt.Sprintf(`%s:"%s"`, gopium.NAME, tag),
+					fmt.Sprintf(`%s:"%s"`, gopium.NAME, gtag),
+					1,
+				)
+				continue
+			}
+		}
 		// in case gopium tag already exists
 		// and force is set - replace tag
 		// in case gopium tag already exists
@@ -133,6 +133,72 @@ func TestTag(t *testing.T) {
 				},
 			},
 		},
+		"non empty struct should be applied to itself with expected tag should be overwritten with markers preserved": {
+			tag: tagf.Names(gopium.StrategyName("test")),
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `json:"test" gopium:"cold;tag"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `json:"test" gopium:"cold;test"`,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to itself with expected tag should be appended to markers": {
+			tag: tags.Names(gopium.StrategyName("test")),
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `json:"cold" gopium:"cold"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `json:"cold" gopium:"cold;test"`,
+					},
+				},
+			},
+		},
+		"non empty struct should be applied to itself with markers preserved on tag removal": {
+			tag: tagf,
+			ctx: context.Background(),
+			o: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `gopium:"cold;test"`,
+					},
+				},
+			},
+			r: gopium.Struct{
+				Name: "test",
+				Fields: []gopium.Field{
+					{
+						Name: "test",
+						Tag:  `gopium:"cold"`,
+					},
+				},
+			},
+		},
 		"complex struct should be applied to itself with expected tag on force": {
 			tag: tagf.Names(gopium.StrategyName("test")),
 			ctx: context.Background(),
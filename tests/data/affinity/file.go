@@ -0,0 +1,30 @@
+//go:build tests_data
+
+package affinity
+
+// Node doc
+type Node struct {
+	key   string
+	val   int
+	next  *Node
+	hits  int64
+	flags uint8
+}
+
+func (n *Node) get(key string) (int, bool) {
+	for ; n != nil; n = n.next {
+		if n.key == key {
+			n.hits++
+			return n.val, true
+		}
+	}
+	return 0, false
+}
+
+func (n *Node) put(key string, val int) {
+	n.key, n.val = key, val
+}
+
+func (n *Node) mark() {
+	n.flags = 1
+}
@@ -0,0 +1,21 @@
+//go:build tests_data
+
+package atomic
+
+import "sync/atomic"
+
+// Counter doc
+type Counter struct {
+	flag  bool
+	hits  int64
+	total uint64
+	plain int64
+	small int32
+}
+
+func (c *Counter) inc() {
+	atomic.AddInt64(&c.hits, 1)
+	atomic.StoreUint64(&(c.total), 1)
+	atomic.AddInt32(&c.small, 1)
+	c.plain++
+}
@@ -0,0 +1,17 @@
+//go:build tests_data
+
+package bitset
+
+// Config doc
+type Config struct {
+	ready          bool `gopium:"bitset"`
+	Name           string
+	debug, verbose bool `gopium:"bitset"`
+	cached         bool
+} // config comment
+
+// Other doc
+type Other struct {
+	Config
+	c Config
+}
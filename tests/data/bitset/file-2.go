@@ -0,0 +1,22 @@
+//go:build tests_data
+
+package bitset
+
+func enable(c *Config) {
+	c.ready = true
+	if c.debug {
+		c.verbose = !c.ready
+	}
+}
+
+func create() Config {
+	return Config{Name: "test", ready: true, debug: false, cached: true}
+}
+
+func verbose(o Other) bool {
+	return o.verbose || o.c.debug
+}
+
+func other() *Other {
+	return &Other{c: Config{verbose: true}}
+}
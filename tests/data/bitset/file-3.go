@@ -0,0 +1,7 @@
+//go:build tests_data
+
+package bitset
+
+func name(c Config) string {
+	return c.Name
+}
@@ -0,0 +1,27 @@
+//go:build tests_data
+
+package deep
+
+type A struct {
+	a bool
+	b int64
+	c bool
+}
+
+type b struct {
+	a bool
+	A A
+	z bool
+}
+
+type C struct {
+	b [2]b
+	c bool
+	p *C
+}
+
+type D struct {
+	a bool
+	d int64
+	z bool
+}
@@ -0,0 +1,47 @@
+//go:build tests_data
+
+package heap
+
+// Small doc
+type Small struct {
+	a bool
+	b int64
+	c bool
+}
+
+// Large doc
+type Large struct {
+	a bool
+	b string
+	c int64
+	d bool
+}
+
+// Smalls doc
+type Smalls []*Small
+
+func small() *Small {
+	return &Small{}
+}
+
+func smalls(n int) []Small {
+	return make([]Small, n)
+}
+
+func large(ls []Large) []Large {
+	return append(ls, Large{}, Large{})
+}
+
+func both() (*Small, *Large) {
+	return new(Small), new(Large)
+}
+
+func ptrs(n int) Smalls {
+	return make(Smalls, n)
+}
+
+func stores(m map[string]Large, k string, ls []Large, i int) (map[int]Small, map[int]Small) {
+	m[k] = Large{}
+	ls[i] = Large{}
+	return map[int]Small{}, make(map[int]Small)
+}
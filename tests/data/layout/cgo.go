@@ -0,0 +1,21 @@
+//go:build tests_data
+
+package layout
+
+// #include <stdint.h>
+import "C"
+
+import "structs"
+
+// Native doc
+type Native struct {
+	a C.int32_t
+	b bool
+}
+
+// Host doc
+type Host struct {
+	_ structs.HostLayout
+	a bool
+	b int64
+}
@@ -0,0 +1,65 @@
+//go:build tests_data
+
+package layout
+
+import (
+	"encoding/binary"
+	"io"
+	"reflect"
+	"unsafe"
+)
+
+// Header doc
+type Header struct {
+	magic   uint16
+	version uint32
+	flags   uint8
+}
+
+// Offset doc
+type Offset struct {
+	a bool
+	b int64
+}
+
+// Raw doc
+type Raw struct {
+	a bool
+	b int64
+}
+
+// Reflected doc
+type Reflected struct {
+	a bool
+	b int64
+}
+
+// Plain doc
+type Plain struct {
+	a bool
+	b int64
+}
+
+func read(r io.Reader) (h Header, err error) {
+	err = binary.Read(r, binary.LittleEndian, &h)
+	return
+}
+
+func offset() uintptr {
+	var o Offset
+	return unsafe.Offsetof(o.b)
+}
+
+func raw(p unsafe.Pointer, off uintptr) *Raw {
+	return (*Raw)(unsafe.Add(p, off))
+}
+
+func plain(p *Plain, v unsafe.Pointer) (unsafe.Pointer, *Plain) {
+	return unsafe.Pointer(p), (*Plain)(v)
+}
+
+func reflected(r Reflected, i int) {
+	_ = reflect.ValueOf(&r).Elem().Field(1)
+	_ = reflect.ValueOf(r).Field(i)
+	_ = reflect.ValueOf(Plain{}).Field(i)
+}
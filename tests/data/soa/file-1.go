@@ -0,0 +1,19 @@
+//go:build tests_data
+
+package soa
+
+import (
+	"strings"
+	t "time"
+)
+
+// Point doc
+type Point struct {
+	X, Y int
+	_    [8]byte
+	At   t.Time `json:"at"`
+	*strings.Builder
+} // point comment
+
+// Empty doc
+type Empty struct{}
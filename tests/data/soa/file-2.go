@@ -0,0 +1,8 @@
+//go:build tests_data
+
+package soa
+
+// Generic doc
+type Generic[T any] struct {
+	V T
+}
@@ -0,0 +1,8 @@
+//go:build tests_data
+
+package soa
+
+// Test doc
+type Test struct {
+	A int
+}
@@ -0,0 +1,11 @@
+//go:build tests_data
+
+package soa
+
+// Clash doc
+type Clash struct {
+	A int
+}
+
+// ClashSoA doc
+type ClashSoA struct{}
@@ -0,0 +1,21 @@
+//go:build tests_data
+
+package split
+
+// Session doc
+type Session struct {
+	ID    int64
+	Name  string `gopium:"cold"`
+	Token string
+	Meta  []byte `json:"meta" gopium:"cold"`
+} // session comment
+
+// Other doc
+type Other struct {
+	S Session
+}
+
+// Wrapper doc
+type Wrapper struct {
+	*Session
+}
@@ -0,0 +1,23 @@
+//go:build tests_data
+
+package split
+
+func name(s *Session) string {
+	return s.Name
+}
+
+func create() Session {
+	return Session{ID: 1, Name: "test", Token: "token"}
+}
+
+func meta(o Other) int {
+	return len(o.S.Meta) + len(o.S.Token)
+}
+
+func promoted(w Wrapper) string {
+	return w.Name + w.Token
+}
+
+func first(ss map[int64]Session) string {
+	return ss[0].Name
+}
@@ -0,0 +1,7 @@
+//go:build tests_data
+
+package split
+
+func token(s Session) string {
+	return s.Token
+}
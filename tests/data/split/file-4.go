@@ -0,0 +1,13 @@
+//go:build tests_data
+
+package split
+
+// Pair doc
+type Pair struct {
+	Key   int64
+	Value string `gopium:"cold"`
+}
+
+func pair() Pair {
+	return Pair{1, "value"}
+}
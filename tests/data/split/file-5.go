@@ -0,0 +1,18 @@
+//go:build tests_data
+
+package split
+
+// Lazy doc
+type Lazy struct {
+	Key   int64
+	Value string `gopium:"cold"`
+}
+
+func lazy() *Lazy {
+	return new(Lazy)
+}
+
+func value(l *Lazy) string {
+	var zero Lazy
+	return l.Value + zero.Value
+}
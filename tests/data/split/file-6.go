@@ -0,0 +1,7 @@
+//go:build tests_data && tests_excluded
+
+package split
+
+func name(s *Session) string {
+	return s.Name + s.Token
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"go/ast"
+	"go/types"
 
 	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
@@ -59,6 +60,6 @@ type Apply struct {
 } // struct size: 16 bytes; struct align: 8 bytes; struct aligned size: 16 bytes; - 🌺 gopium @1pkg
 
 // Apply mock implementation
-func (a Apply) Apply(context.Context, *ast.Package, gopium.Locator, gopium.Categorized) (*ast.Package, error) {
+func (a Apply) Apply(context.Context, *ast.Package, *types.Package, gopium.Locator, gopium.Categorized) (*ast.Package, error) {
 	return nil, a.Err
 }
@@ -0,0 +1,86 @@
+package typepkg
+
+import (
+	"context"
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// Check type checks provided ast package
+// and returns collected types info back,
+// it reuses already type checked package imports
+// to resolve ast package dependencies
+// and falls back to source importer otherwise.
+// note: all type checking errors are ignored
+// as info is still collected for valid nodes
+func Check(
+	ctx context.Context,
+	tpkg *types.Package,
+	apkg *ast.Package,
+	fset *token.FileSet,
+) (*types.Info, error) {
+	// manage context actions
+	// in case of cancelation
+	// stop execution
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	// collect all ast package files
+	// in stable order
+	names := make([]string, 0, len(apkg.Files))
+	for name := range apkg.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	files := make([]*ast.File, 0, len(names))
+	for _, name := range names {
+		files = append(files, apkg.Files[name])
+	}
+	// prepare types checker config and info
+	imp := imports{
+		pkgs:     make(map[string]*types.Package),
+		fallback: importer.ForCompiler(fset, "source", nil),
+	}
+	if tpkg != nil {
+		for _, pkg := range tpkg.Imports() {
+			imp.pkgs[pkg.Path()] = pkg
+		}
+	}
+	cfg := types.Config{
+		Importer: imp,
+		Error:    func(error) {},
+	}
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
+	}
+	// we could skip err check here
+	// as all errors are ignored
+	_, _ = cfg.Check(apkg.Name, fset, files, info)
+	return info, ctx.Err()
+}
+
+// imports defines types importer implementation
+// that resolves already imported packages first
+type imports struct {
+	pkgs     map[string]*types.Package `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	fallback types.Importer            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_        [8]byte                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 24 bytes; - 🌺 gopium @1pkg
+
+// Import imports implementation
+func (imp imports) Import(path string) (*types.Package, error) {
+	if pkg, ok := imp.pkgs[path]; ok {
+		return pkg, nil
+	}
+	return imp.fallback.Import(path)
+}
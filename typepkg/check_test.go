@@ -0,0 +1,117 @@
+package typepkg
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ipkg := types.NewPackage("test/imp", "imp")
+	ipkg.Scope().Insert(types.NewTypeName(token.NoPos, ipkg, "T", types.Typ[types.Int]))
+	ipkg.MarkComplete()
+	tpkg := types.NewPackage("test/test", "test")
+	tpkg.SetImports([]*types.Package{ipkg})
+	table := map[string]struct {
+		ctx  context.Context
+		tpkg *types.Package
+		src  string
+		defs []string
+		uses []string
+		err  error
+	}{
+		"empty package should return empty info": {
+			ctx: context.Background(),
+			src: `package test`,
+		},
+		"package should return expected info": {
+			ctx: context.Background(),
+			src: `
+package test
+
+type A struct {
+	a int
+	b string
+}
+
+func f(x A) int {
+	return x.a
+}
+`,
+			defs: []string{"A", "a", "b", "f", "x"},
+			uses: []string{"A", "a", "int", "int", "string", "x"},
+		},
+		"package with imports should return expected info": {
+			ctx:  context.Background(),
+			tpkg: tpkg,
+			src: `
+package test
+
+import "test/imp"
+
+var v imp.T
+`,
+			defs: []string{"v"},
+			uses: []string{"T", "imp"},
+		},
+		"invalid package should return partial info": {
+			ctx: context.Background(),
+			src: `
+package test
+
+var v = u
+`,
+			defs: []string{"v"},
+		},
+		"package should return error on canceled context": {
+			ctx: cctx,
+			src: `package test`,
+			err: context.Canceled,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", tcase.src, parser.AllErrors)
+			if !reflect.DeepEqual(err, nil) {
+				t.Fatalf("actual %v doesn't equal to expected %v", err, nil)
+			}
+			apkg := &ast.Package{Name: "test", Files: map[string]*ast.File{"test.go": file}}
+			// exec
+			info, err := Check(tcase.ctx, tcase.tpkg, apkg, fset)
+			// check
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			if info == nil {
+				return
+			}
+			var defs, uses []string
+			for id, obj := range info.Defs {
+				if obj != nil {
+					defs = append(defs, id.Name)
+				}
+			}
+			for id := range info.Uses {
+				uses = append(uses, id.Name)
+			}
+			sort.Strings(defs)
+			sort.Strings(uses)
+			if !reflect.DeepEqual(defs, tcase.defs) {
+				t.Errorf("actual %v doesn't equal to expected %v", defs, tcase.defs)
+			}
+			if !reflect.DeepEqual(uses, tcase.uses) {
+				t.Errorf("actual %v doesn't equal to expected %v", uses, tcase.uses)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"go/parser"
 	"go/token"
 	"go/types"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -122,11 +123,20 @@ func (p *ParserXToolPackagesAst) ParseAst(ctx context.Context, src ...byte) (*as
 		}, NewLocator(fset), err
 	}
 	// otherwise use parser parse dir
+	// with files loaded for types package
+	// as parser ignores build constraints
 	dir := filepath.Join(p.Root, p.Path)
+	files := p.files(ctx, dir)
 	pkgs, err := parser.ParseDir(
 		fset,
 		dir,
-		nil,
+		func(fi fs.FileInfo) bool {
+			if files == nil {
+				return true
+			}
+			name, err := filepath.Abs(filepath.Join(dir, fi.Name()))
+			return err == nil && files[name]
+		},
 		p.ModeAst,
 	)
 	// on any error just propagate it
@@ -144,6 +154,37 @@ func (p *ParserXToolPackagesAst) ParseAst(ctx context.Context, src ...byte) (*as
 	return nil, nil, fmt.Errorf("ast package %q wasn't found at %q", p.Pattern, dir)
 }
 
+// files collects absolute paths of package files
+// that match build constraints with the same
+// config as types package is loaded with,
+// in case of any loading error nil is returned
+func (p *ParserXToolPackagesAst) files(ctx context.Context, dir string) map[string]bool {
+	cfg := &packages.Config{
+		Context:    ctx,
+		Dir:        dir,
+		Mode:       packages.NeedName | packages.NeedFiles,
+		Env:        p.BuildEnv,
+		BuildFlags: p.BuildFlags,
+		Tests:      true,
+	}
+	pkgs, err := packages.Load(cfg, "")
+	if err != nil || len(pkgs) == 0 {
+		return nil
+	}
+	files := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, name := range pkg.GoFiles {
+			if name, err := filepath.Abs(name); err == nil {
+				files[name] = true
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	return files
+}
+
 // validPackage checks package name against provided package pattern and path.
 // It preformns shallow sanity package name check to be able to validate
 // packages outside of gopath and versioned packages.
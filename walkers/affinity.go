@@ -0,0 +1,84 @@
+package walkers
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// affinities defines map of struct fields ids
+// to their co-accessed fields affinities
+type affinities map[string][]gopium.Affinity
+
+// accesses defines struct fields co-accesses
+// collecting helper that counts number of
+// functions accessing each pair of fields
+type accesses map[*types.Var]map[*types.Var]int64
+
+// inspect checks provided node for function
+// declaration and counts all pairs of local
+// struct fields accessed together inside it
+// e.g. `func (x *T) f() { x.a = x.b }`
+func (acs accesses) inspect(locals map[types.Object]bool, info *types.Info, node ast.Node) {
+	fn, ok := node.(*ast.FuncDecl)
+	if !ok || fn.Body == nil {
+		return
+	}
+	// collect all accessed fields
+	// grouped by their structs
+	groups := make(map[*types.TypeName][]*types.Var)
+	used := make(map[*types.Var]bool)
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		sel, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		s, ok := info.Selections[sel]
+		if !ok || s.Kind() != types.FieldVal || len(s.Index()) != 1 {
+			return true
+		}
+		v, ok := s.Obj().(*types.Var)
+		if !ok || !locals[v] || used[v] {
+			return true
+		}
+		if tn, ok := layoutname(s.Recv()); ok && locals[tn] {
+			groups[tn] = append(groups[tn], v)
+			used[v] = true
+		}
+		return true
+	})
+	// count all accessed
+	// pairs of the same struct fields
+	for _, vars := range groups {
+		for _, vi := range vars {
+			for _, vj := range vars {
+				if vi == vj {
+					continue
+				}
+				if acs[vi] == nil {
+					acs[vi] = make(map[*types.Var]int64)
+				}
+				acs[vi][vj]++
+			}
+		}
+	}
+}
+
+// affinities converts collected co-accesses
+// to affinities sorted by fields names
+func (acs accesses) affinities(loc gopium.Locator) affinities {
+	afs := make(affinities, len(acs))
+	for v, counts := range acs {
+		fafs := make([]gopium.Affinity, 0, len(counts))
+		for vc, count := range counts {
+			fafs = append(fafs, gopium.Affinity{Name: vc.Name(), Count: count})
+		}
+		sort.Slice(fafs, func(i, j int) bool {
+			return fafs[i].Name < fafs[j].Name
+		})
+		afs[fid(loc, v)] = fafs
+	}
+	return afs
+}
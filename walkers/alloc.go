@@ -0,0 +1,130 @@
+package walkers
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// allocations defines map of structs ids
+// to their profile allocations weights
+type allocations map[string]int64
+
+// sites defines structs allocation sites
+// collecting helper that keeps structs ids
+// allocated on each source line
+type sites map[token.Position][]string
+
+// importances defines map of structs ids
+// to their static allocation sites importance scores
+type importances map[string]int64
+
+// inspect checks provided node for any
+// local struct allocation or storing expression
+// and adds its source line to the collection
+// e.g. `&T{}`, `new(T)`, `make([]T, n)`,
+// `append(ts, t)`, `[]T{t}`, `map[K]T{}` or `ts[i] = t`
+func (ss sites) inspect(loc gopium.Locator, locals map[types.Object]bool, info *types.Info, node ast.Node) {
+	switch n := node.(type) {
+	case *ast.UnaryExpr:
+		if _, ok := unparen(n.X).(*ast.CompositeLit); ok && n.Op == token.AND {
+			ss.add(loc, locals, node, info.TypeOf(n))
+		}
+	case *ast.CompositeLit:
+		ss.add(loc, locals, node, info.TypeOf(n))
+	case *ast.CallExpr:
+		if id, ok := unparen(n.Fun).(*ast.Ident); ok {
+			if b, ok := info.Uses[id].(*types.Builtin); ok {
+				switch b.Name() {
+				case "new", "make", "append":
+					ss.add(loc, locals, node, info.TypeOf(n))
+				}
+			}
+		}
+	case *ast.AssignStmt:
+		// values stored into maps or slices
+		// are counted as their containers sites
+		for _, lhs := range n.Lhs {
+			if idx, ok := unparen(lhs).(*ast.IndexExpr); ok {
+				ss.add(loc, locals, idx, info.TypeOf(idx.X))
+			}
+		}
+	}
+}
+
+// add adds provided node source line to the collection
+// if provided type allocates local struct
+func (ss sites) add(loc gopium.Locator, locals map[types.Object]bool, node ast.Node, t types.Type) {
+	tn, ok := allocname(t)
+	if !ok || !locals[tn] {
+		return
+	}
+	// keep only file and line of position
+	// as profiles are collected per line
+	pos := loc.Root().Position(node.Pos())
+	pos = token.Position{Filename: pos.Filename, Line: pos.Line}
+	ss[pos] = append(ss[pos], loc.ID(tn.Pos()))
+}
+
+// allocations converts collected allocation sites
+// to allocations by splitting profile weight
+// of each line evenly among structs allocated on it
+func (ss sites) allocations(prof gopium.Profile) allocations {
+	als := make(allocations)
+	// skip allocations if no profile provided
+	if prof == nil {
+		return als
+	}
+	for pos, ids := range ss {
+		weight := prof.Weight(pos.Filename, pos.Line)
+		if weight <= 0 {
+			continue
+		}
+		for _, id := range ids {
+			als[id] += weight / int64(len(ids))
+		}
+	}
+	return als
+}
+
+// importances converts collected allocation sites
+// to rough static importance scores
+// by counting all structs allocation sites,
+// so structs allocated in many places
+// are considered more important
+func (ss sites) importances() importances {
+	ims := make(importances)
+	for _, ids := range ss {
+		for _, id := range ids {
+			ims[id]++
+		}
+	}
+	return ims
+}
+
+// allocname returns struct type name
+// allocated by pointer, slice or map type
+// e.g. `*T`, `[]T` or `map[K]T`
+func allocname(t types.Type) (*types.TypeName, bool) {
+	if t == nil {
+		return nil, false
+	}
+	switch tp := t.Underlying().(type) {
+	case *types.Pointer:
+		t = tp.Elem()
+	case *types.Slice:
+		t = tp.Elem()
+	case *types.Map:
+		t = tp.Elem()
+	default:
+		return nil, false
+	}
+	if tp, ok := t.(*types.Named); ok {
+		if _, ok := tp.Underlying().(*types.Struct); ok {
+			return tp.Obj(), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,67 @@
+package walkers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// atomics defines set of struct fields ids
+// that are used by 64-bit sync/atomic functions
+type atomics map[string]bool
+
+// atomicvar returns struct field var
+// in case provided node is 64-bit sync/atomic
+// function call on the field address
+func atomicvar(info *types.Info, node ast.Node) (*types.Var, bool) {
+	// check that node is a call
+	// to 64-bit sync/atomic function
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "sync/atomic" {
+		return nil, false
+	}
+	if name := fn.Name(); !strings.HasSuffix(name, "Int64") && !strings.HasSuffix(name, "Uint64") {
+		return nil, false
+	}
+	// check that the first argument
+	// is struct field address
+	un, ok := call.Args[0].(*ast.UnaryExpr)
+	if !ok || un.Op != token.AND {
+		return nil, false
+	}
+	x := un.X
+	for {
+		paren, ok := x.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		x = paren.X
+	}
+	fsel, ok := x.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+	if s, ok := info.Selections[fsel]; ok && s.Kind() == types.FieldVal {
+		v, ok := s.Obj().(*types.Var)
+		return v, ok
+	}
+	return nil, false
+}
+
+// fid builds struct field id
+// from field var position and name
+func fid(loc gopium.Locator, v *types.Var) string {
+	return fmt.Sprintf("%s:%s", loc.ID(v.Pos()), v.Name())
+}
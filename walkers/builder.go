@@ -13,14 +13,23 @@ const (
 	AstGo     gopium.WalkerName = "ast_go"
 	AstGoTree gopium.WalkerName = "ast_go_tree"
 	AstGopium gopium.WalkerName = "ast_gopium"
+	// wast soa walkers
+	SoaStd gopium.WalkerName = "soa_std"
+	SoaGo  gopium.WalkerName = "soa_go"
 	// wout walkers
 	FileJsonb gopium.WalkerName = "file_json"
 	FileXmlb  gopium.WalkerName = "file_xml"
 	FileCsvb  gopium.WalkerName = "file_csv"
 	FileMdt   gopium.WalkerName = "file_md_table"
 	// wdiff walkers
-	SizeAlignFileMdt gopium.WalkerName = "size_align_file_md_table"
-	FieldsFileHtmlt  gopium.WalkerName = "fields_file_html_table"
+	SizeAlignFileMdt           gopium.WalkerName = "size_align_file_md_table"
+	SizeAlignImportanceFileMdt gopium.WalkerName = "size_align_importance_file_md_table"
+	FieldsFileHtmlt            gopium.WalkerName = "fields_file_html_table"
+	// wheap walkers
+	HeapFileJsonb gopium.WalkerName = "heap_file_json"
+	HeapFileXmlb  gopium.WalkerName = "heap_file_xml"
+	HeapFileCsvb  gopium.WalkerName = "heap_file_csv"
+	HeapFileMdt   gopium.WalkerName = "heap_file_md_table"
 )
 
 // Builder defines types gopium.WalkerBuilder implementation
@@ -29,10 +38,11 @@ type Builder struct {
 	Parser  gopium.Parser  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	Exposer gopium.Exposer `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	Printer gopium.Printer `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	Profile gopium.Profile `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	Deep    bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	Bref    bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_       [14]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 48 bytes; - 🌺 gopium @1pkg
+	_       [62]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 64 bytes; - 🌺 gopium @1pkg
 
 // Build Builder implementation
 func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
@@ -45,7 +55,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Printer,
 			b.Deep,
 			b.Bref,
-		), nil
+		).Profile(b.Profile), nil
 	case AstGo:
 		return astgo.With(
 			b.Parser,
@@ -53,7 +63,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Printer,
 			b.Deep,
 			b.Bref,
-		), nil
+		).Profile(b.Profile), nil
 	case AstGoTree:
 		return astgotree.With(
 			b.Parser,
@@ -61,7 +71,7 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Printer,
 			b.Deep,
 			b.Bref,
-		), nil
+		).Profile(b.Profile), nil
 	case AstGopium:
 		return astgopium.With(
 			b.Parser,
@@ -69,7 +79,24 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Printer,
 			b.Deep,
 			b.Bref,
-		), nil
+		).Profile(b.Profile), nil
+	// wast soa walkers
+	case SoaStd:
+		return soastd.With(
+			b.Parser,
+			b.Exposer,
+			b.Printer,
+			b.Deep,
+			b.Bref,
+		).Profile(b.Profile), nil
+	case SoaGo:
+		return soago.With(
+			b.Parser,
+			b.Exposer,
+			b.Printer,
+			b.Deep,
+			b.Bref,
+		).Profile(b.Profile), nil
 	// wout walkers
 	case FileJsonb:
 		return filejson.With(
@@ -77,28 +104,28 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Exposer,
 			b.Deep,
 			b.Bref,
-		), nil
+		).Profile(b.Profile), nil
 	case FileXmlb:
 		return filexml.With(
 			b.Parser,
 			b.Exposer,
 			b.Deep,
 			b.Bref,
-		), nil
+		).Profile(b.Profile), nil
 	case FileCsvb:
 		return filecsv.With(
 			b.Parser,
 			b.Exposer,
 			b.Deep,
 			b.Bref,
-		), nil
+		).Profile(b.Profile), nil
 	case FileMdt:
 		return filemdt.With(
 			b.Parser,
 			b.Exposer,
 			b.Deep,
 			b.Bref,
-		), nil
+		).Profile(b.Profile), nil
 	// wdiff walkers
 	case SizeAlignFileMdt:
 		return safilemdt.With(
@@ -106,13 +133,53 @@ func (b Builder) Build(name gopium.WalkerName) (gopium.Walker, error) {
 			b.Exposer,
 			b.Deep,
 			b.Bref,
-		), nil
+		).Profile(b.Profile), nil
+	case SizeAlignImportanceFileMdt:
+		return saifilemdt.With(
+			b.Parser,
+			b.Exposer,
+			b.Deep,
+			b.Bref,
+		).Profile(b.Profile), nil
 	case FieldsFileHtmlt:
 		return ffilehtml.With(
 			b.Parser,
 			b.Exposer,
 			b.Deep,
 			b.Bref,
+		).Profile(b.Profile), nil
+	// wheap walkers
+	case HeapFileJsonb:
+		return heapfilejson.With(
+			b.Parser,
+			b.Exposer,
+			b.Profile,
+			b.Deep,
+			b.Bref,
+		), nil
+	case HeapFileXmlb:
+		return heapfilexml.With(
+			b.Parser,
+			b.Exposer,
+			b.Profile,
+			b.Deep,
+			b.Bref,
+		), nil
+	case HeapFileCsvb:
+		return heapfilecsv.With(
+			b.Parser,
+			b.Exposer,
+			b.Profile,
+			b.Deep,
+			b.Bref,
+		), nil
+	case HeapFileMdt:
+		return heapfilemdt.With(
+			b.Parser,
+			b.Exposer,
+			b.Profile,
+			b.Deep,
+			b.Bref,
 		), nil
 	default:
 		return nil, fmt.Errorf("walker %q wasn't found", name)
@@ -31,7 +31,7 @@ func TestBuilder(t *testing.T) {
 				b.Printer,
 				b.Deep,
 				b.Bref,
-			),
+			).Profile(b.Profile),
 		},
 		"`ast_go` name should return expected walker": {
 			name: AstGo,
@@ -41,7 +41,7 @@ func TestBuilder(t *testing.T) {
 				b.Printer,
 				b.Deep,
 				b.Bref,
-			),
+			).Profile(b.Profile),
 		},
 		"`ast_go_tree` name should return expected walker": {
 			name: AstGoTree,
@@ -51,7 +51,7 @@ func TestBuilder(t *testing.T) {
 				b.Printer,
 				b.Deep,
 				b.Bref,
-			),
+			).Profile(b.Profile),
 		},
 		"`ast_gopium` name should return expected walker": {
 			name: AstGopium,
@@ -61,7 +61,28 @@ func TestBuilder(t *testing.T) {
 				b.Printer,
 				b.Deep,
 				b.Bref,
-			),
+			).Profile(b.Profile),
+		},
+		// wast soa walkers
+		"`soa_std` name should return expected walker": {
+			name: SoaStd,
+			w: soastd.With(
+				b.Parser,
+				b.Exposer,
+				b.Printer,
+				b.Deep,
+				b.Bref,
+			).Profile(b.Profile),
+		},
+		"`soa_go` name should return expected walker": {
+			name: SoaGo,
+			w: soago.With(
+				b.Parser,
+				b.Exposer,
+				b.Printer,
+				b.Deep,
+				b.Bref,
+			).Profile(b.Profile),
 		},
 		// wout walkers
 		"`file_json` name should return expected walker": {
@@ -71,7 +92,7 @@ func TestBuilder(t *testing.T) {
 				b.Exposer,
 				b.Deep,
 				b.Bref,
-			),
+			).Profile(b.Profile),
 		},
 		"`file_xml` name should return expected walker": {
 			name: FileXmlb,
@@ -80,7 +101,7 @@ func TestBuilder(t *testing.T) {
 				b.Exposer,
 				b.Deep,
 				b.Bref,
-			),
+			).Profile(b.Profile),
 		},
 		"`file_csv` name should return expected walker": {
 			name: FileCsvb,
@@ -89,7 +110,7 @@ func TestBuilder(t *testing.T) {
 				b.Exposer,
 				b.Deep,
 				b.Bref,
-			),
+			).Profile(b.Profile),
 		},
 		"`file_md_table` name should return expected walker": {
 			name: FileMdt,
@@ -98,7 +119,7 @@ func TestBuilder(t *testing.T) {
 				b.Exposer,
 				b.Deep,
 				b.Bref,
-			),
+			).Profile(b.Profile),
 		},
 		// wdiff walkers
 		"`size_align_file_md_table` name should return expected walker": {
@@ -108,7 +129,16 @@ func TestBuilder(t *testing.T) {
 				b.Exposer,
 				b.Deep,
 				b.Bref,
-			),
+			).Profile(b.Profile),
+		},
+		"`size_align_importance_file_md_table` name should return expected walker": {
+			name: SizeAlignImportanceFileMdt,
+			w: saifilemdt.With(
+				b.Parser,
+				b.Exposer,
+				b.Deep,
+				b.Bref,
+			).Profile(b.Profile),
 		},
 		"`fields_file_html_table` name should return expected walker": {
 			name: FieldsFileHtmlt,
@@ -117,6 +147,47 @@ func TestBuilder(t *testing.T) {
 				b.Exposer,
 				b.Deep,
 				b.Bref,
+			).Profile(b.Profile),
+		},
+		// wheap walkers
+		"`heap_file_json` name should return expected walker": {
+			name: HeapFileJsonb,
+			w: heapfilejson.With(
+				b.Parser,
+				b.Exposer,
+				b.Profile,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		"`heap_file_xml` name should return expected walker": {
+			name: HeapFileXmlb,
+			w: heapfilexml.With(
+				b.Parser,
+				b.Exposer,
+				b.Profile,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		"`heap_file_csv` name should return expected walker": {
+			name: HeapFileCsvb,
+			w: heapfilecsv.With(
+				b.Parser,
+				b.Exposer,
+				b.Profile,
+				b.Deep,
+				b.Bref,
+			),
+		},
+		"`heap_file_md_table` name should return expected walker": {
+			name: HeapFileMdt,
+			w: heapfilemdt.With(
+				b.Parser,
+				b.Exposer,
+				b.Profile,
+				b.Deep,
+				b.Bref,
 			),
 		},
 		// others
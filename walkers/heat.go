@@ -0,0 +1,64 @@
+package walkers
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// heats defines map of struct fields ids
+// to their profile heat weights
+type heats map[string]int64
+
+// samples defines struct fields source lines
+// collecting helper that keeps all distinct
+// source lines accessing each field
+type samples map[*types.Var]map[token.Position]bool
+
+// inspect checks provided node for selector
+// expression and collects source line
+// of local struct field accessed by it
+// e.g. `x.a++`
+func (sms samples) inspect(fset *token.FileSet, locals map[types.Object]bool, info *types.Info, node ast.Node) {
+	sel, ok := node.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	s, ok := info.Selections[sel]
+	if !ok || s.Kind() != types.FieldVal {
+		return
+	}
+	v, ok := s.Obj().(*types.Var)
+	if !ok || !locals[v] {
+		return
+	}
+	// keep only file and line of position
+	// as profiles are collected per line
+	pos := fset.Position(sel.Sel.Pos())
+	if sms[v] == nil {
+		sms[v] = make(map[token.Position]bool)
+	}
+	sms[v][token.Position{Filename: pos.Filename, Line: pos.Line}] = true
+}
+
+// heats converts collected fields source lines
+// to heats by summing profile weights of the lines
+func (sms samples) heats(loc gopium.Locator, prof gopium.Profile) heats {
+	hts := make(heats)
+	// skip heats if no profile provided
+	if prof == nil {
+		return hts
+	}
+	for v, lines := range sms {
+		var heat int64
+		for pos := range lines {
+			heat += prof.Weight(pos.Filename, pos.Line)
+		}
+		if heat > 0 {
+			hts[fid(loc, v)] = heat
+		}
+	}
+	return hts
+}
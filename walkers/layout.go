@@ -0,0 +1,279 @@
+package walkers
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/1pkg/gopium/gopium"
+)
+
+// layouts defines set of structs ids
+// with layout sensitivity reasons
+// for structs which fields order is observable
+type layouts map[string]string
+
+// sensitives defines layout sensitive
+// structs collecting helper that
+// accumulates all found reasons
+type sensitives map[string]map[string]bool
+
+// add adds layout sensitive struct
+// with reason to the collection,
+// non local or non struct types are skipped
+func (s sensitives) add(loc gopium.Locator, locals map[types.Object]bool, t types.Type, reason string) {
+	tn, ok := layoutname(t)
+	if !ok || !locals[tn] {
+		return
+	}
+	id := loc.ID(tn.Pos())
+	if s[id] == nil {
+		s[id] = make(map[string]bool)
+	}
+	s[id][reason] = true
+}
+
+// layouts converts collected structs
+// to layouts set with sorted reasons
+func (s sensitives) layouts() layouts {
+	lts := make(layouts, len(s))
+	for id, rset := range s {
+		reasons := make([]string, 0, len(rset))
+		for reason := range rset {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+		lts[id] = strings.Join(reasons, ", ")
+	}
+	return lts
+}
+
+// inspect checks provided node for
+// any fields order observable usage
+// and adds found structs to the collection
+// e.g. `unsafe.Offsetof(x.f)`, `(*T)(unsafe.Pointer(&b))`,
+// `binary.Read(r, order, &x)`, `reflect.ValueOf(x).Field(0)`,
+// `C.int` fields or `structs.HostLayout` markers
+func (s sensitives) inspect(loc gopium.Locator, locals map[types.Object]bool, info *types.Info, node ast.Node) {
+	switch n := node.(type) {
+	case *ast.CallExpr:
+		// check unsafe.Pointer conversions
+		// that reinterpret memory as other type
+		// or are used in pointer arithmetic
+		if tv, ok := info.Types[n.Fun]; ok && tv.IsType() && len(n.Args) == 1 {
+			src, ok := unsafesrc(info, n.Args[0])
+			if !ok {
+				return
+			}
+			switch {
+			// `(*T)(unsafe.Pointer(p))` where `p` isn't `*T`
+			// or `(*T)(unsafe.Add(p, off))`
+			case !isunsafeptr(tv.Type) && !isuintptr(tv.Type):
+				if src == nil || !types.Identical(src, tv.Type) {
+					s.add(loc, locals, tv.Type, "unsafe.Pointer")
+					s.add(loc, locals, src, "unsafe.Pointer")
+				}
+			// `uintptr(unsafe.Pointer(p))`
+			case isuintptr(tv.Type):
+				s.add(loc, locals, src, "unsafe.Pointer")
+			}
+			return
+		}
+		sel, ok := n.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		// check unsafe.Offsetof and unsafe.Add builtins
+		if b, ok := info.Uses[sel.Sel].(*types.Builtin); ok {
+			switch {
+			case b.Name() == "Offsetof" && len(n.Args) == 1:
+				if fsel, ok := unparen(n.Args[0]).(*ast.SelectorExpr); ok {
+					s.add(loc, locals, info.TypeOf(fsel.X), "unsafe.Offsetof")
+				}
+			case b.Name() == "Add" && len(n.Args) == 2:
+				if src, ok := unsafesrc(info, n.Args[0]); ok {
+					s.add(loc, locals, src, "unsafe.Pointer")
+				}
+			}
+			return
+		}
+		fn, ok := info.Uses[sel.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil {
+			return
+		}
+		switch fn.Pkg().Path() {
+		case "encoding/binary":
+			// check binary data functions
+			// on struct values
+			switch fn.Name() {
+			case "Read", "Write":
+				if len(n.Args) == 3 {
+					s.add(loc, locals, info.TypeOf(n.Args[2]), "binary."+fn.Name())
+				}
+			case "Size":
+				if len(n.Args) == 1 {
+					s.add(loc, locals, info.TypeOf(n.Args[0]), "binary.Size")
+				}
+			}
+		case "reflect":
+			// check reflect field access
+			// with constant index
+			if fn.Name() != "Field" || len(n.Args) != 1 {
+				return
+			}
+			if tv, ok := info.Types[n.Args[0]]; !ok || tv.Value == nil {
+				return
+			}
+			if t, ok := reflected(info, sel.X); ok {
+				s.add(loc, locals, t, "reflect.Value.Field")
+			}
+		}
+	case *ast.TypeSpec:
+		// check struct fields for
+		// cgo types and host layout markers
+		st, ok := n.Type.(*ast.StructType)
+		if !ok {
+			return
+		}
+		obj, ok := info.Defs[n.Name]
+		if !ok || obj == nil {
+			return
+		}
+		for _, f := range st.Fields.List {
+			fsel, ok := unparen(f.Type).(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			x, ok := fsel.X.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			pkg, ok := info.Uses[x].(*types.PkgName)
+			if !ok {
+				continue
+			}
+			switch path := pkg.Imported().Path(); {
+			case path == "C":
+				s.add(loc, locals, obj.Type(), "cgo")
+			case path == "structs" && fsel.Sel.Name == "HostLayout":
+				s.add(loc, locals, obj.Type(), "structs.HostLayout")
+			}
+		}
+	}
+}
+
+// layoutname returns struct type name
+// for provided type dereferencing
+// pointers, arrays and slices
+func layoutname(t types.Type) (*types.TypeName, bool) {
+	for t != nil {
+		switch tp := t.(type) {
+		case *types.Pointer:
+			t = tp.Elem()
+		case *types.Array:
+			t = tp.Elem()
+		case *types.Slice:
+			t = tp.Elem()
+		case *types.Named:
+			if _, ok := tp.Underlying().(*types.Struct); !ok {
+				return nil, false
+			}
+			return tp.Obj(), true
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// reflected returns reflected value type
+// for `reflect.ValueOf(x)` and `reflect.TypeOf(x)`
+// expressions optionally followed by `Elem()` calls
+func reflected(info *types.Info, expr ast.Expr) (types.Type, bool) {
+	for {
+		call, ok := unparen(expr).(*ast.CallExpr)
+		if !ok {
+			return nil, false
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil, false
+		}
+		fn, ok := info.Uses[sel.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "reflect" {
+			return nil, false
+		}
+		switch fn.Name() {
+		case "Elem":
+			expr = sel.X
+		case "ValueOf", "TypeOf":
+			if len(call.Args) != 1 {
+				return nil, false
+			}
+			return info.TypeOf(call.Args[0]), true
+		default:
+			return nil, false
+		}
+	}
+}
+
+// unsafesrc returns source pointer type
+// of unsafe pointer expression `unsafe.Pointer(p)`,
+// for pointer arithmetic expressions
+// `unsafe.Pointer(uintptr(p) + off)` and `unsafe.Add(p, off)`
+// it returns nil type, other expressions are skipped
+func unsafesrc(info *types.Info, expr ast.Expr) (types.Type, bool) {
+	call, ok := unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil, false
+	}
+	// `unsafe.Add(p, off)` builtin
+	if sel, ok := unparen(call.Fun).(*ast.SelectorExpr); ok {
+		if b, ok := info.Uses[sel.Sel].(*types.Builtin); ok && b.Name() == "Add" {
+			return nil, true
+		}
+	}
+	// `unsafe.Pointer(p)` conversion
+	tv, ok := info.Types[call.Fun]
+	if !ok || !tv.IsType() || !isunsafeptr(tv.Type) || len(call.Args) != 1 {
+		return nil, false
+	}
+	switch t := info.TypeOf(call.Args[0]); {
+	case t == nil:
+		return nil, false
+	case isuintptr(t):
+		return nil, true
+	default:
+		if _, ok := t.Underlying().(*types.Pointer); ok {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// isuintptr checks if provided type
+// is `uintptr` type
+func isuintptr(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Kind() == types.Uintptr
+}
+
+// isunsafeptr checks if provided type
+// is `unsafe.Pointer` type
+func isunsafeptr(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Kind() == types.UnsafePointer
+}
+
+// unparen returns expression
+// without enclosing parentheses
+func unparen(expr ast.Expr) ast.Expr {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.X
+	}
+}
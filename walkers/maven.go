@@ -1,7 +1,9 @@
 package walkers
 
 import (
+	"context"
 	"go/types"
+	"regexp"
 	"sync"
 
 	"github.com/1pkg/gopium/collections"
@@ -11,12 +13,14 @@ import (
 // ptrsizealign defines data transfer
 // object that holds type triplet
 // of ptr, size and align vals
+// and optional triplets for
+// additional compiler/arch targets
 type ptrsizealign struct {
-	ptr   int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	size  int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	align int64   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_     [8]byte `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 0 bytes; - 🌺 gopium @1pkg
+	ptr   int64                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	size  int64                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	align int64                   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	archs map[string]ptrsizealign `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
 
 // maven defines visiting helper
 // that aggregates some useful
@@ -25,9 +29,20 @@ type maven struct {
 	exp   gopium.Exposer         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	loc   gopium.Locator         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	ref   *collections.Reference `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	us    *lazy                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ats   atomics                `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	afs   affinities             `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	hts   heats                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	store sync.Map               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_     [48]byte               `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 72 bytes; - 🌺 gopium @1pkg
+	archs []arch                 `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 136 bytes; struct align: 8 bytes; struct aligned size: 136 bytes; struct ptr scan size: 120 bytes; - 🌺 gopium @1pkg
+
+// arch defines compiler/arch target
+// with its own exposer
+type arch struct {
+	target string         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	exp    gopium.Exposer `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 32 bytes; struct align: 8 bytes; struct aligned size: 32 bytes; struct ptr scan size: 32 bytes; - 🌺 gopium @1pkg
 
 // has defines struct store id helper
 // that uses locator to build id
@@ -66,16 +81,32 @@ func (m *maven) enum(name string, st *types.Struct) gopium.Struct {
 		f := st.Field(i)
 		// get size and align for field
 		sa := m.refpsa(f.Type())
+		// get size and align for field
+		// for all additional targets
+		var archs []gopium.Arch
+		for _, a := range m.archs {
+			asa := m.tarpsa(a.exp, a.target, f.Type())
+			archs = append(archs, gopium.Arch{
+				Target: a.target,
+				Size:   asa.size,
+				Align:  asa.align,
+				Ptr:    asa.ptr,
+			})
+		}
 		// fill field structure
 		r.Fields = append(r.Fields, gopium.Field{
-			Name:     f.Name(),
-			Type:     m.exp.Name(f.Type()),
-			Size:     sa.size,
-			Align:    sa.align,
-			Ptr:      sa.ptr,
-			Tag:      st.Tag(i),
-			Exported: f.Exported(),
-			Embedded: f.Embedded(),
+			Name:       f.Name(),
+			Type:       m.exp.Name(f.Type()),
+			Size:       sa.size,
+			Align:      sa.align,
+			Ptr:        sa.ptr,
+			Tag:        st.Tag(i),
+			Exported:   f.Exported(),
+			Embedded:   f.Embedded(),
+			Atomic:     m.ats[fid(m.loc, f)],
+			Archs:      archs,
+			Affinities: m.afs[fid(m.loc, f)],
+			Heat:       m.hts[fid(m.loc, f)],
 		})
 	}
 	return r
@@ -86,13 +117,21 @@ func (m *maven) enum(name string, st *types.Struct) gopium.Struct {
 // if it has been provided
 // or uses exposer to expose type size
 func (m *maven) refpsa(t types.Type) ptrsizealign {
+	return m.tarpsa(m.exp, "", t)
+}
+
+// tarpsa defines ptr and size and align getter
+// with reference helper for provided exposer
+// and compiler/arch target, empty target
+// stands for default maven target
+func (m *maven) tarpsa(exp gopium.Exposer, target string, t types.Type) ptrsizealign {
 	// in case we don't have a reference
-	// just use default exposer size
+	// just use exposer size
 	if m.ref == nil {
 		return ptrsizealign{
-			ptr:   m.exp.Ptr(t),
-			size:  m.exp.Size(t),
-			align: m.exp.Align(t),
+			ptr:   exp.Ptr(t),
+			size:  exp.Size(t),
+			align: exp.Align(t),
 		}
 	}
 	// for refsize only named structures
@@ -106,7 +145,7 @@ func (m *maven) refpsa(t types.Type) ptrsizealign {
 			return ptrsizealign{}
 		}
 		// n > 0
-		sa := m.refpsa(tp.Elem())
+		sa := m.tarpsa(exp, target, tp.Elem())
 		sa.size = collections.Align(sa.size, sa.align)*(n-1) + sa.size
 		sa.ptr = sa.ptr * n
 		return sa
@@ -117,17 +156,82 @@ func (m *maven) refpsa(t types.Type) ptrsizealign {
 		}
 		// get id for named structures
 		id := m.loc.ID(tp.Obj().Pos())
-		// get size of the structure from ref
+		// get size of the structure
+		// for the target from ref
 		if sa, ok := m.ref.Get(id).(ptrsizealign); ok {
-			return sa
+			if target == "" {
+				sa.archs = nil
+				return sa
+			}
+			if asa, ok := sa.archs[target]; ok {
+				return asa
+			}
 		}
 	}
-	// just use default exposer size
+	// just use exposer size
 	return ptrsizealign{
-		ptr:   m.exp.Ptr(t),
-		size:  m.exp.Size(t),
-		align: m.exp.Align(t),
+		ptr:   exp.Ptr(t),
+		size:  exp.Size(t),
+		align: exp.Align(t),
+	}
+}
+
+// nested collects names of all structures
+// declared on the scope that are nested by value
+// into structures with names matching regex,
+// nested structures declared on other scopes
+// or behind pointers, slices, maps, etc.
+// don't affect parents layout and are skipped
+func (m *maven) nested(s *types.Scope, r *regexp.Regexp) map[string]bool {
+	names := make(map[string]bool)
+	// walk defines recursive helper
+	// that goes through type and
+	// collects nested structures names
+	var walk func(types.Type)
+	walk = func(t types.Type) {
+		switch tp := t.(type) {
+		case *types.Array:
+			walk(tp.Elem())
+		case *types.Struct:
+			for i := 0; i < tp.NumFields(); i++ {
+				walk(tp.Field(i).Type())
+			}
+		case *types.Named:
+			// in case it's not a struct
+			// declared on the scope
+			// or it's already collected skip it
+			tn := tp.Obj()
+			if _, ok := tp.Underlying().(*types.Struct); !ok || tn.Parent() != s || names[tn.Name()] {
+				return
+			}
+			names[tn.Name()] = true
+			walk(tp.Underlying())
+		}
+	}
+	// go through all structures
+	// with names that match regex
+	for _, name := range s.Names() {
+		if !r.MatchString(name) {
+			continue
+		}
+		if tn, ok := s.Lookup(name).(*types.TypeName); ok && !tn.IsAlias() {
+			if st, ok := tn.Type().Underlying().(*types.Struct); ok {
+				walk(st)
+			}
+		}
+	}
+	return names
+}
+
+// sensitive checks lazily collected package layouts
+// and returns layout sensitivity reason
+// if the structure layout is observable
+func (m *maven) sensitive(ctx context.Context, id string) (string, error) {
+	us, err := m.us.usages(ctx)
+	if err != nil {
+		return "", err
 	}
+	return us.lts[id], nil
 }
 
 // refst helps to create struct
@@ -141,7 +245,35 @@ func (m *maven) refst(name string) func(gopium.Struct) {
 	return func(st gopium.Struct) {
 		// calculate structure align, aligned size and ptr size
 		stsize, stalign, ptrsize := collections.SizeAlignPtr(st)
+		sa := ptrsizealign{size: stsize, align: stalign, ptr: ptrsize}
+		// calculate the same sizes for all additional
+		// targets from fields targets sizes
+		if len(m.archs) > 0 {
+			sa.archs = make(map[string]ptrsizealign, len(m.archs))
+			for _, a := range m.archs {
+				sa.archs[a.target] = tarst(st, a.target)
+			}
+		}
 		// set ref key size and align
-		m.ref.Set(name, ptrsizealign{size: stsize, align: stalign, ptr: ptrsize})
+		m.ref.Set(name, sa)
+	}
+}
+
+// tarst calculates structure align, aligned size
+// and ptr size for provided compiler/arch target
+// using fields sizes for the target,
+// fields without target sizes keep their own sizes
+func tarst(st gopium.Struct, target string) ptrsizealign {
+	tst := collections.CopyStruct(st)
+	for i := range tst.Fields {
+		f := &tst.Fields[i]
+		for _, a := range f.Archs {
+			if a.Target == target {
+				f.Size, f.Align, f.Ptr = a.Size, a.Align, a.Ptr
+				break
+			}
+		}
 	}
+	stsize, stalign, ptrsize := collections.SizeAlignPtr(tst)
+	return ptrsizealign{size: stsize, align: stalign, ptr: ptrsize}
 }
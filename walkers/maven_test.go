@@ -80,7 +80,14 @@ func TestMavenEnum(t *testing.T) {
 	// prepare
 	ref := collections.NewReference(true)
 	ref.Alloc("test")
-	ref.Set("test", ptrsizealign{ptr: 32, size: 32, align: 32})
+	ref.Set("test", ptrsizealign{
+		ptr:   32,
+		size:  32,
+		align: 32,
+		archs: map[string]ptrsizealign{
+			"gc/386": {ptr: 12, size: 12, align: 4},
+		},
+	})
 	m := maven{
 		exp: mocks.Maven{
 			Types: map[string]mocks.Type{
@@ -124,9 +131,10 @@ func TestMavenEnum(t *testing.T) {
 	)
 	tp := types.NewNamed(types.NewTypeName(token.Pos(0), nil, "test", sti), sti, nil)
 	table := map[string]struct {
-		name string
-		tst  *types.Struct
-		st   gopium.Struct
+		name  string
+		tst   *types.Struct
+		archs []arch
+		st    gopium.Struct
 	}{
 		"custom type should return expected struct": {
 			name: "test-st",
@@ -158,6 +166,45 @@ func TestMavenEnum(t *testing.T) {
 				},
 			},
 		},
+		"custom type with archs should return expected struct": {
+			name: "test-st",
+			tst:  types.NewStruct([]*types.Var{types.NewVar(token.Pos(0), nil, "a", types.Typ[types.String])}, nil),
+			archs: []arch{
+				{
+					target: "gc/386",
+					exp: mocks.Maven{
+						Types: map[string]mocks.Type{
+							"string": {
+								Name:  "string",
+								Size:  8,
+								Align: 4,
+								Ptr:   4,
+							},
+						},
+					},
+				},
+			},
+			st: gopium.Struct{
+				Name: "test-st",
+				Fields: []gopium.Field{
+					{
+						Name:  "a",
+						Type:  "string",
+						Size:  16,
+						Align: 8,
+						Ptr:   16,
+						Archs: []gopium.Arch{
+							{
+								Target: "gc/386",
+								Size:   8,
+								Align:  4,
+								Ptr:    4,
+							},
+						},
+					},
+				},
+			},
+		},
 		"custom type with backref should return expected struct": {
 			name: "test-st",
 			tst:  types.NewStruct([]*types.Var{types.NewVar(token.Pos(0), nil, "v", tp)}, nil),
@@ -174,9 +221,40 @@ func TestMavenEnum(t *testing.T) {
 				},
 			},
 		},
+		"custom type with archs and backref should return expected struct": {
+			name: "test-st",
+			tst:  types.NewStruct([]*types.Var{types.NewVar(token.Pos(0), nil, "v", types.NewArray(tp, 2))}, nil),
+			archs: []arch{
+				{
+					target: "gc/386",
+					exp:    mocks.Maven{},
+				},
+			},
+			st: gopium.Struct{
+				Name: "test-st",
+				Fields: []gopium.Field{
+					{
+						Name:  "v",
+						Size:  64,
+						Align: 32,
+						Ptr:   64,
+						Archs: []gopium.Arch{
+							{
+								Target: "gc/386",
+								Size:   24,
+								Align:  4,
+								Ptr:    24,
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
+			// prepare
+			m.archs = tcase.archs
 			// exec
 			st := m.enum(tcase.name, tcase.tst)
 			// check
@@ -292,6 +370,14 @@ func TestMavenRefst(t *testing.T) {
 	// prepare
 	m := maven{ref: collections.NewReference(true)}
 	f1, f2, f3 := m.refst("test-1"), m.refst("test-2"), m.refst("test-3")
+	ma := maven{ref: m.ref, archs: []arch{{target: "gc/386"}}}
+	f4 := ma.refst("test-4")
+	f4(gopium.Struct{
+		Fields: []gopium.Field{
+			{Size: 8, Align: 8, Ptr: 8, Archs: []gopium.Arch{{Target: "gc/386", Size: 4, Align: 4, Ptr: 4}}},
+			{Size: 1, Align: 1},
+		},
+	})
 	f2(gopium.Struct{
 		Fields: []gopium.Field{
 			{Size: 4, Align: 4, Ptr: 4},
@@ -330,6 +416,17 @@ func TestMavenRefst(t *testing.T) {
 			key: "test-3",
 			sa:  ptrsizealign{ptr: 5, size: 12, align: 4},
 		},
+		"test-4 key should return expected result with archs": {
+			key: "test-4",
+			sa: ptrsizealign{
+				ptr:   8,
+				size:  16,
+				align: 8,
+				archs: map[string]ptrsizealign{
+					"gc/386": {ptr: 4, size: 8, align: 4},
+				},
+			},
+		},
 	}
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
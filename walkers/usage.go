@@ -0,0 +1,122 @@
+package walkers
+
+import (
+	"context"
+	"go/ast"
+	"go/types"
+	"sync"
+
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+// usages defines package wide structs
+// and fields usages data transfer object
+// collected from type checked ast package
+type usages struct {
+	ats atomics     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	lts layouts     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	afs affinities  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	hts heats       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	als allocations `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	ims importances `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_   [16]byte    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 48 bytes; - 🌺 gopium @1pkg
+
+// collect helps to collect package usages
+// by type checking ast package and scanning it
+// for 64-bit sync/atomic functions calls
+// on struct fields addresses
+// e.g. `atomic.AddInt64(&x.f, 1)`,
+// for fields order observable usages
+// e.g. `binary.Read(r, order, &x)`
+// for fields accessed together
+// inside the same function,
+// for fields heats and structs allocations
+// weighted by provided profile if any
+// and for structs allocation sites importances
+func collect(ctx context.Context, xp gopium.AstParser, tpkg *types.Package, prof gopium.Profile) (usages, error) {
+	// use parser to parse ast pkg data
+	pkg, loc, err := xp.ParseAst(ctx)
+	if err != nil {
+		return usages{}, err
+	}
+	// type check the whole package
+	info, err := typepkg.Check(ctx, tpkg, pkg, loc.Root())
+	if err != nil {
+		return usages{}, err
+	}
+	// collect all package local objects
+	// to skip imported fields
+	locals := make(map[types.Object]bool, len(info.Defs))
+	for _, obj := range info.Defs {
+		if obj != nil {
+			locals[obj] = true
+		}
+	}
+	// go through all package files
+	// and collect atomic fields,
+	// layout sensitive structs,
+	// fields co-accesses and source lines
+	// and structs allocation sites
+	ats, lss, acs, sms, ss := make(atomics), make(sensitives), make(accesses), make(samples), make(sites)
+	for _, file := range pkg.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if v, ok := atomicvar(info, node); ok && locals[v] {
+				ats[fid(loc, v)] = true
+			}
+			lss.inspect(loc, locals, info, node)
+			acs.inspect(locals, info, node)
+			sms.inspect(loc.Root(), locals, info, node)
+			ss.inspect(loc, locals, info, node)
+			return true
+		})
+	}
+	return usages{
+		ats: ats,
+		lts: lss.layouts(),
+		afs: acs.affinities(loc),
+		hts: sms.heats(loc, prof),
+		als: ss.allocations(prof),
+		ims: ss.importances(),
+	}, ctx.Err()
+}
+
+// lazy defines package usages holder
+// that collects package usages only once
+// on the first demand and caches them,
+// so walkers with strategies that don't
+// require usages skip package type checking
+type lazy struct {
+	xp   gopium.AstParser `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	prof gopium.Profile   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	err  error            `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	tpkg *types.Package   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	us   usages           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	once sync.Once        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_    [56]byte         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 192 bytes; struct align: 8 bytes; struct aligned size: 192 bytes; struct ptr scan size: 104 bytes; - 🌺 gopium @1pkg
+
+// lazyusages helps to create lazy package usages holder
+// for provided parser, in case parser can't parse
+// ast package data empty usages are provided
+func lazyusages(p gopium.TypeParser, tpkg *types.Package, prof gopium.Profile) *lazy {
+	xp, _ := p.(gopium.AstParser)
+	return &lazy{xp: xp, tpkg: tpkg, prof: prof}
+}
+
+// usages collects package usages on the first call
+// and returns cached usages on the next calls
+func (l *lazy) usages(ctx context.Context) (usages, error) {
+	// in case there is no holder
+	// just return empty usages
+	if l == nil {
+		return usages{}, nil
+	}
+	l.once.Do(func() {
+		if l.xp != nil {
+			l.us, l.err = collect(ctx, l.xp, l.tpkg, l.prof)
+		}
+	})
+	return l.us, l.err
+}
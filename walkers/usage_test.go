@@ -0,0 +1,403 @@
+package walkers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+)
+
+func TestCollect(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := map[string]struct {
+		ctx  context.Context
+		p    gopium.AstParser
+		prof gopium.Profile
+		us   usages
+		err  error
+	}{
+		"empty pkg should collect nothing": {
+			ctx: context.Background(),
+			p:   data.NewParser("empty"),
+			us: usages{
+				ats: atomics{},
+				lts: layouts{},
+				afs: affinities{},
+				hts: heats{},
+				als: allocations{},
+				ims: importances{},
+			},
+		},
+		"single pkg should collect nothing": {
+			ctx: context.Background(),
+			p:   data.NewParser("single"),
+			us: usages{
+				ats: atomics{},
+				lts: layouts{},
+				afs: affinities{},
+				hts: heats{},
+				als: allocations{},
+				ims: importances{},
+			},
+		},
+		"atomic pkg should collect expected atomic fields": {
+			ctx: context.Background(),
+			p:   data.NewParser("atomic"),
+			us: usages{
+				ats: atomics{
+					"tests_data_atomic_file.go:10:hits":  true,
+					"tests_data_atomic_file.go:11:total": true,
+				},
+				lts: layouts{},
+				afs: affinities{
+					"tests_data_atomic_file.go:10:hits": {
+						{
+							Name:  "plain",
+							Count: 1,
+						},
+						{
+							Name:  "small",
+							Count: 1,
+						},
+						{
+							Name:  "total",
+							Count: 1,
+						},
+					},
+					"tests_data_atomic_file.go:11:total": {
+						{
+							Name:  "hits",
+							Count: 1,
+						},
+						{
+							Name:  "plain",
+							Count: 1,
+						},
+						{
+							Name:  "small",
+							Count: 1,
+						},
+					},
+					"tests_data_atomic_file.go:12:plain": {
+						{
+							Name:  "hits",
+							Count: 1,
+						},
+						{
+							Name:  "small",
+							Count: 1,
+						},
+						{
+							Name:  "total",
+							Count: 1,
+						},
+					},
+					"tests_data_atomic_file.go:13:small": {
+						{
+							Name:  "hits",
+							Count: 1,
+						},
+						{
+							Name:  "plain",
+							Count: 1,
+						},
+						{
+							Name:  "total",
+							Count: 1,
+						},
+					},
+				},
+				hts: heats{},
+				als: allocations{},
+				ims: importances{},
+			},
+		},
+		"layout pkg should collect expected layout sensitive structs": {
+			ctx: context.Background(),
+			p:   data.NewParser("layout"),
+			us: usages{
+				ats: atomics{},
+				lts: layouts{
+					"tests_data_layout_cgo.go:11":  "cgo",
+					"tests_data_layout_cgo.go:17":  "structs.HostLayout",
+					"tests_data_layout_file.go:13": "binary.Read",
+					"tests_data_layout_file.go:20": "unsafe.Offsetof",
+					"tests_data_layout_file.go:26": "unsafe.Pointer",
+					"tests_data_layout_file.go:32": "reflect.Value.Field",
+				},
+				afs: affinities{},
+				hts: heats{},
+				als: allocations{},
+				ims: importances{},
+			},
+		},
+		"affinity pkg should collect expected fields affinities": {
+			ctx: context.Background(),
+			p:   data.NewParser("affinity"),
+			us: usages{
+				ats: atomics{},
+				lts: layouts{},
+				afs: affinities{
+					"tests_data_affinity_file.go:7:key": {
+						{
+							Name:  "hits",
+							Count: 1,
+						},
+						{
+							Name:  "next",
+							Count: 1,
+						},
+						{
+							Name:  "val",
+							Count: 2,
+						},
+					},
+					"tests_data_affinity_file.go:8:val": {
+						{
+							Name:  "hits",
+							Count: 1,
+						},
+						{
+							Name:  "key",
+							Count: 2,
+						},
+						{
+							Name:  "next",
+							Count: 1,
+						},
+					},
+					"tests_data_affinity_file.go:9:next": {
+						{
+							Name:  "hits",
+							Count: 1,
+						},
+						{
+							Name:  "key",
+							Count: 1,
+						},
+						{
+							Name:  "val",
+							Count: 1,
+						},
+					},
+					"tests_data_affinity_file.go:10:hits": {
+						{
+							Name:  "key",
+							Count: 1,
+						},
+						{
+							Name:  "next",
+							Count: 1,
+						},
+						{
+							Name:  "val",
+							Count: 1,
+						},
+					},
+				},
+				hts: heats{},
+				als: allocations{},
+				ims: importances{},
+			},
+		},
+		"affinity pkg should collect expected fields heats from profile": {
+			ctx: context.Background(),
+			p:   data.NewParser("affinity"),
+			prof: fmtio.Pprof{
+				Lines: map[string]map[int]int64{
+					"affinity/file.go": {
+						16:  10,
+						17:  30,
+						25:  5,
+						100: 7,
+					},
+				},
+			},
+			us: usages{
+				ats: atomics{},
+				lts: layouts{},
+				afs: affinities{
+					"tests_data_affinity_file.go:7:key": {
+						{
+							Name:  "hits",
+							Count: 1,
+						},
+						{
+							Name:  "next",
+							Count: 1,
+						},
+						{
+							Name:  "val",
+							Count: 2,
+						},
+					},
+					"tests_data_affinity_file.go:8:val": {
+						{
+							Name:  "hits",
+							Count: 1,
+						},
+						{
+							Name:  "key",
+							Count: 2,
+						},
+						{
+							Name:  "next",
+							Count: 1,
+						},
+					},
+					"tests_data_affinity_file.go:9:next": {
+						{
+							Name:  "hits",
+							Count: 1,
+						},
+						{
+							Name:  "key",
+							Count: 1,
+						},
+						{
+							Name:  "val",
+							Count: 1,
+						},
+					},
+					"tests_data_affinity_file.go:10:hits": {
+						{
+							Name:  "key",
+							Count: 1,
+						},
+						{
+							Name:  "next",
+							Count: 1,
+						},
+						{
+							Name:  "val",
+							Count: 1,
+						},
+					},
+				},
+				hts: heats{
+					"tests_data_affinity_file.go:7:key":   15,
+					"tests_data_affinity_file.go:8:val":   5,
+					"tests_data_affinity_file.go:10:hits": 30,
+				},
+				als: allocations{},
+				ims: importances{},
+			},
+		},
+		"heap pkg should collect expected structs allocations from profile": {
+			ctx: context.Background(),
+			p:   data.NewParser("heap"),
+			prof: fmtio.Pprof{
+				Lines: map[string]map[int]int64{
+					"heap/file.go": {
+						24: 100,
+						28: 200,
+						32: 400,
+						36: 60,
+						40: 70,
+					},
+				},
+			},
+			us: usages{
+				ats: atomics{},
+				lts: layouts{},
+				afs: affinities{},
+				hts: heats{},
+				als: allocations{
+					"tests_data_heap_file.go:6":  330,
+					"tests_data_heap_file.go:13": 430,
+				},
+				ims: importances{
+					"tests_data_heap_file.go:6":  5,
+					"tests_data_heap_file.go:13": 4,
+				},
+			},
+		},
+		"atomic pkg should return error on canceled context": {
+			ctx: cctx,
+			p:   data.NewParser("atomic"),
+			err: context.Canceled,
+		},
+		"atomic pkg should return error on parser error": {
+			ctx: context.Background(),
+			p:   mocks.Parser{Asterr: errors.New("test-1")},
+			err: errors.New("test-1"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			us, err := collect(tcase.ctx, tcase.p, nil, tcase.prof)
+			// check
+			if !reflect.DeepEqual(us, tcase.us) {
+				t.Errorf("actual %v doesn't equal to expected %v", us, tcase.us)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
+
+func TestLazyUsages(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		l   *lazy
+		us  usages
+		err error
+	}{
+		"nil holder should collect nothing": {},
+		"holder without ast parser should collect nothing": {
+			l: lazyusages(nil, nil, nil),
+		},
+		"holder should return cached usages": {
+			l: &lazy{us: usages{ats: atomics{"test": true}}},
+			us: usages{
+				ats: atomics{"test": true},
+			},
+		},
+		"single pkg holder should collect nothing": {
+			l: lazyusages(data.NewParser("single"), nil, nil),
+			us: usages{
+				ats: atomics{},
+				lts: layouts{},
+				afs: affinities{},
+				hts: heats{},
+				als: allocations{},
+				ims: importances{},
+			},
+		},
+		"holder should return error on parser error": {
+			l:   lazyusages(mocks.Parser{Asterr: errors.New("test-1")}, nil, nil),
+			err: errors.New("test-1"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			us, err := tcase.l.usages(context.Background())
+			// check
+			if !reflect.DeepEqual(us, tcase.us) {
+				t.Errorf("actual %v doesn't equal to expected %v", us, tcase.us)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// exec again to check cached results
+			us, err = tcase.l.usages(context.Background())
+			// check
+			if !reflect.DeepEqual(us, tcase.us) {
+				t.Errorf("actual %v doesn't equal to expected %v", us, tcase.us)
+			}
+			if !reflect.DeepEqual(err, tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+		})
+	}
+}
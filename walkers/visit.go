@@ -2,12 +2,15 @@ package walkers
 
 import (
 	"context"
+	"fmt"
 	"go/types"
 	"regexp"
+	"strings"
 	"sync"
 
 	"github.com/1pkg/gopium/collections"
 	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/typepkg"
 )
 
 // applied encapsulates visited by strategy
@@ -34,14 +37,14 @@ type govisit func(context.Context, *types.Scope)
 type prepare func() (*maven, context.CancelFunc)
 
 // with helps to create prepare func
-// with exposer, locator and backref
-func with(exp gopium.Exposer, loc gopium.Locator, bref bool) prepare {
+// with exposer, locator, lazy package usages and backref
+func with(exp gopium.Exposer, loc gopium.Locator, us *lazy, bref bool) prepare {
 	return func() (*maven, context.CancelFunc) {
 		// create visiting maven with reference
 		// and return it back,
 		// with ref prune cancelation func
 		ref := collections.NewReference(bref)
-		return &maven{exp: exp, loc: loc, ref: ref}, ref.Prune
+		return &maven{exp: exp, loc: loc, us: us, ref: ref}, ref.Prune
 	}
 }
 
@@ -62,6 +65,29 @@ func (p prepare) visit(regex *regexp.Regexp, stg gopium.Strategy, ch appliedCh,
 		// prepare visiting maven
 		m, cancel := p()
 		defer cancel()
+		// nested strategies require backref
+		// to apply nested structures first
+		// even if backref flag is not set
+		if nests(stg) && m.ref == nil {
+			m.ref = collections.NewReference(true)
+			defer m.ref.Prune()
+		}
+		// arch strategies require
+		// fields sizes for all their targets
+		m.archs = archs(stg)
+		// usage strategies require package
+		// usages before structures are exposed
+		// in case any error happened
+		// just push error to the chan
+		if usage(stg) {
+			us, err := m.us.usages(ctx)
+			if err != nil {
+				ch <- applied{Err: err}
+				close(ch)
+				return
+			}
+			m.ats, m.afs, m.hts = us.ats, us.afs, us.hts
+		}
 		// determinate which function
 		// should be applied for visiting
 		// depends on deep flag
@@ -162,6 +188,13 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 	// enumerate all structs on the scope first
 	names := s.Names()
 	vclos := make([]func(), 0, len(names))
+	// in case of nested strategy
+	// collect nested structures names
+	// that should be visited as well
+	var nested map[string]bool
+	if nests(stg) {
+		nested = m.nested(s, r)
+	}
 	for _, name := range names {
 		// manage context actions
 		// in case of cancelation
@@ -174,7 +207,9 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 		default:
 		}
 		// check if object name doesn't matches regex
-		if !r.MatchString(name) {
+		// and object isn't nested structure
+		match := r.MatchString(name)
+		if !match && !nested[name] {
 			continue
 		}
 		// in case it does and object is
@@ -201,8 +236,25 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 					// convert original struct
 					// to inner gopium format
 					o := m.enum(name, st)
+					// nested structures that don't match regex
+					// are kept intact and only propagate
+					// their sizes to parents structures
+					if !match {
+						notif(o)
+						return
+					}
 					// apply provided strategy
 					r, err := stg.Apply(ctx, o)
+					// in case strategy result changes
+					// structure fields offsets or size
+					// and its layout is sensitive to it
+					// keep original structure intact
+					if err == nil && relayouts(o, r) {
+						var reason string
+						if reason, err = m.sensitive(ctx, id); err == nil && reason != "" {
+							r = intact(o, reason)
+						}
+					}
 					// notify ref with result structure
 					notif(r)
 					// and push results to the chan
@@ -238,3 +290,95 @@ func vscope(ctx context.Context, s *types.Scope, r *regexp.Regexp, stg gopium.St
 	// wait until all visits are finished
 	wg.Wait()
 }
+
+// nests checks if provided strategy
+// is nested strategy that requires
+// nested structures to be applied first
+func nests(stg gopium.Strategy) bool {
+	nstg, ok := stg.(gopium.NestedStrategy)
+	return ok && nstg.Nested()
+}
+
+// usage checks if provided strategy
+// is usage strategy that requires
+// package usages to be collected
+func usage(stg gopium.Strategy) bool {
+	ustg, ok := stg.(gopium.UsageStrategy)
+	return ok && ustg.Usages()
+}
+
+// archs checks if provided strategy
+// is arch strategy and creates exposers
+// for all its compiler/arch targets,
+// unsupported targets are skipped
+func archs(stg gopium.Strategy) []arch {
+	astg, ok := stg.(gopium.ArchStrategy)
+	if !ok {
+		return nil
+	}
+	var archs []arch
+	for _, target := range astg.Archs() {
+		compiler, tarch := "gc", target
+		if i := strings.IndexByte(target, '/'); i >= 0 {
+			compiler, tarch = target[:i], target[i+1:]
+		}
+		exp, err := typepkg.NewMavenGoTypes(compiler, tarch)
+		if err != nil {
+			continue
+		}
+		archs = append(archs, arch{target: target, exp: exp})
+	}
+	return archs
+}
+
+// relayouts checks if result structure
+// changes offset of any original named
+// structure field or total structure size,
+// pads offsets are not observable and skipped
+func relayouts(o gopium.Struct, r gopium.Struct) bool {
+	// compare total structures sizes
+	osize, _, _ := collections.SizeAlignPtr(o)
+	rsize, _, _ := collections.SizeAlignPtr(r)
+	if osize != rsize {
+		return true
+	}
+	// collect named fields offsets
+	// for both structures
+	offsets := func(st gopium.Struct) map[string]int64 {
+		var offset int64
+		offs := make(map[string]int64, len(st.Fields))
+		collections.WalkStruct(st, 0, func(pad int64, fields ...gopium.Field) {
+			offset += pad
+			for _, f := range fields {
+				if f.Name != "_" {
+					offs[f.Name] = offset
+				}
+				offset += f.Size
+			}
+		})
+		return offs
+	}
+	ooffs, roffs := offsets(o), offsets(r)
+	if len(ooffs) != len(roffs) {
+		return true
+	}
+	for name, off := range ooffs {
+		if roff, ok := roffs[name]; !ok || roff != off {
+			return true
+		}
+	}
+	return false
+}
+
+// intact keeps original structure
+// with layout sensitivity reason comment
+// instead of strategy result
+func intact(o gopium.Struct, reason string) gopium.Struct {
+	r := collections.CopyStruct(o)
+	r.Comment = append(r.Comment, fmt.Sprintf(
+		"// struct layout is sensitive to fields offsets: %s; struct is kept intact; - %s",
+		reason,
+		gopium.STAMP,
+	))
+	return r
+}
@@ -18,6 +18,14 @@ import (
 
 func TestWithVisit(t *testing.T) {
 	// prepare
+	pckdeep, err := strategies.Builder{}.Build(strategies.PackDeep)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	parch, err := strategies.Builder{}.Build("memory_pack_arch(targets=[amd64,386])")
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
 	table := map[string]struct {
 		exp  gopium.Exposer
 		loc  gopium.Locator
@@ -62,6 +70,28 @@ func TestWithVisit(t *testing.T) {
 			ctx:  context.Background(),
 			s:    &types.Scope{},
 		},
+		"with visit should return expected govisit func with nested strategy without bref flag": {
+			exp:  mocks.Maven{},
+			loc:  mocks.Locator{},
+			bref: false,
+			r:    regexp.MustCompile(`.*`),
+			stg:  pckdeep,
+			ch:   make(appliedCh),
+			deep: false,
+			ctx:  context.Background(),
+			s:    &types.Scope{},
+		},
+		"with visit should return expected govisit func with arch strategy": {
+			exp:  mocks.Maven{},
+			loc:  mocks.Locator{},
+			bref: true,
+			r:    regexp.MustCompile(`.*`),
+			stg:  parch,
+			ch:   make(appliedCh),
+			deep: false,
+			ctx:  context.Background(),
+			s:    &types.Scope{},
+		},
 		"with visit should return expected govisit func without all flags": {
 			exp: mocks.Maven{},
 			loc: mocks.Locator{},
@@ -75,7 +105,7 @@ func TestWithVisit(t *testing.T) {
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
 			// exec
-			gvisit := with(tcase.exp, tcase.loc, tcase.bref).
+			gvisit := with(tcase.exp, tcase.loc, nil, tcase.bref).
 				visit(tcase.r, tcase.stg, tcase.ch, tcase.deep)
 			gvisit(tcase.ctx, tcase.s)
 			// check
@@ -101,16 +131,30 @@ func TestVscope(t *testing.T) {
 	if !reflect.DeepEqual(err, nil) {
 		t.Fatalf("actual %v doesn't equal to %v", err, nil)
 	}
+	pckdeep, err := b.Build(strategies.PackDeep)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	nlex, err := b.Build(strategies.NLexDesc)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
 	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
 	if !reflect.DeepEqual(err, nil) {
 		t.Fatalf("actual %v doesn't equal to %v", err, nil)
 	}
+	cb := strategies.Builder{Curator: m}
+	cache, err := cb.Build(strategies.CacheL1D)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
 	table := map[string]struct {
 		ctx context.Context
 		r   *regexp.Regexp
 		m   gopium.Maven
 		p   gopium.TypeParser
 		loc gopium.Locator
+		lts layouts
 		stg gopium.Strategy
 		sts map[string]gopium.Struct
 		err error
@@ -161,6 +205,129 @@ func TestVscope(t *testing.T) {
 				},
 			},
 		},
+		"single struct pkg should apply non reordering strategy to layout sensitive struct": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			m:   m,
+			p:   data.NewParser("single"),
+			lts: layouts{"tests_data_single_file.go:5": "binary.Read"},
+			stg: pck,
+			sts: map[string]gopium.Struct{
+				"tests_data_single_file.go:5": {
+					Name: "Single",
+					Fields: []gopium.Field{
+						{
+							Name:     "A",
+							Type:     "string",
+							Size:     16,
+							Align:    8,
+							Ptr:      8,
+							Exported: true,
+						},
+						{
+							Name:     "B",
+							Type:     "string",
+							Size:     16,
+							Align:    8,
+							Ptr:      8,
+							Exported: true,
+						},
+						{
+							Name:     "C",
+							Type:     "string",
+							Size:     16,
+							Align:    8,
+							Ptr:      8,
+							Exported: true,
+						},
+					},
+				},
+			},
+		},
+		"single struct pkg should keep layout sensitive struct intact on reordering strategy": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			m:   m,
+			p:   data.NewParser("single"),
+			lts: layouts{"tests_data_single_file.go:5": "binary.Read"},
+			stg: nlex,
+			sts: map[string]gopium.Struct{
+				"tests_data_single_file.go:5": {
+					Name: "Single",
+					Comment: []string{
+						"// struct layout is sensitive to fields offsets: binary.Read; struct is kept intact; - 🌺 gopium @1pkg",
+					},
+					Fields: []gopium.Field{
+						{
+							Name:     "A",
+							Type:     "string",
+							Size:     16,
+							Align:    8,
+							Ptr:      8,
+							Exported: true,
+						},
+						{
+							Name:     "B",
+							Type:     "string",
+							Size:     16,
+							Align:    8,
+							Ptr:      8,
+							Exported: true,
+						},
+						{
+							Name:     "C",
+							Type:     "string",
+							Size:     16,
+							Align:    8,
+							Ptr:      8,
+							Exported: true,
+						},
+					},
+				},
+			},
+		},
+		"single struct pkg should keep layout sensitive struct intact on padding strategy": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			m:   m,
+			p:   data.NewParser("single"),
+			lts: layouts{"tests_data_single_file.go:5": "binary.Read"},
+			stg: cache,
+			sts: map[string]gopium.Struct{
+				"tests_data_single_file.go:5": {
+					Name: "Single",
+					Comment: []string{
+						"// struct layout is sensitive to fields offsets: binary.Read; struct is kept intact; - 🌺 gopium @1pkg",
+					},
+					Fields: []gopium.Field{
+						{
+							Name:     "A",
+							Type:     "string",
+							Size:     16,
+							Align:    8,
+							Ptr:      8,
+							Exported: true,
+						},
+						{
+							Name:     "B",
+							Type:     "string",
+							Size:     16,
+							Align:    8,
+							Ptr:      8,
+							Exported: true,
+						},
+						{
+							Name:     "C",
+							Type:     "string",
+							Size:     16,
+							Align:    8,
+							Ptr:      8,
+							Exported: true,
+						},
+					},
+				},
+			},
+		},
 		"single struct pkg should visit nothing on canceled context": {
 			ctx: cctx,
 			r:   regexp.MustCompile(`.*`),
@@ -519,6 +686,95 @@ func TestVscope(t *testing.T) {
 				},
 			},
 		},
+		"deep structs pkg should visit only matching structs with nested structs sizes": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`C`),
+			m:   m,
+			p:   data.NewParser("deep"),
+			stg: pckdeep,
+			sts: map[string]gopium.Struct{
+				"tests_data_deep_file.go:17": {
+					Name: "C",
+					Fields: []gopium.Field{
+						{
+							Name:  "p",
+							Type:  "*github.com/1pkg/gopium/tests/data/deep.C",
+							Size:  8,
+							Align: 8,
+							Ptr:   8,
+						},
+						{
+							Name:  "b",
+							Type:  "[2]github.com/1pkg/gopium/tests/data/deep.b",
+							Size:  80,
+							Align: 8,
+						},
+						{
+							Name:  "c",
+							Type:  "bool",
+							Size:  1,
+							Align: 1,
+						},
+					},
+				},
+			},
+		},
+		"deep structs pkg should visit expected structs with nested structs first": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`A|C`),
+			m:   m,
+			p:   data.NewParser("deep"),
+			stg: pckdeep,
+			sts: map[string]gopium.Struct{
+				"tests_data_deep_file.go:5": {
+					Name: "A",
+					Fields: []gopium.Field{
+						{
+							Name:  "b",
+							Type:  "int64",
+							Size:  8,
+							Align: 8,
+						},
+						{
+							Name:  "a",
+							Type:  "bool",
+							Size:  1,
+							Align: 1,
+						},
+						{
+							Name:  "c",
+							Type:  "bool",
+							Size:  1,
+							Align: 1,
+						},
+					},
+				},
+				"tests_data_deep_file.go:17": {
+					Name: "C",
+					Fields: []gopium.Field{
+						{
+							Name:  "p",
+							Type:  "*github.com/1pkg/gopium/tests/data/deep.C",
+							Size:  8,
+							Align: 8,
+							Ptr:   8,
+						},
+						{
+							Name:  "b",
+							Type:  "[2]github.com/1pkg/gopium/tests/data/deep.b",
+							Size:  64,
+							Align: 8,
+						},
+						{
+							Name:  "c",
+							Type:  "bool",
+							Size:  1,
+							Align: 1,
+						},
+					},
+				},
+			},
+		},
 	}
 	for name, tcase := range table {
 		t.Run(name, func(t *testing.T) {
@@ -528,7 +784,7 @@ func TestVscope(t *testing.T) {
 				t.Fatalf("actual %v doesn't equal to %v", err, nil)
 			}
 			ref := collections.NewReference(true)
-			m := &maven{exp: m, loc: loc, ref: ref}
+			m := &maven{exp: m, loc: loc, us: &lazy{us: usages{lts: tcase.lts}}, ref: ref}
 			m.store.Store("", struct{}{})
 			if tcase.loc != nil {
 				m.loc = tcase.loc
@@ -1128,3 +1384,85 @@ func TestVdeep(t *testing.T) {
 		})
 	}
 }
+
+func TestRelayouts(t *testing.T) {
+	// prepare
+	table := map[string]struct {
+		o   gopium.Struct
+		r   gopium.Struct
+		res bool
+	}{
+		"empty structs shouldn't be relayouted": {},
+		"the same structs shouldn't be relayouted": {
+			o: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 8, Align: 8}, {Name: "b", Size: 8, Align: 8}},
+			},
+			r: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 8, Align: 8}, {Name: "b", Size: 8, Align: 8}},
+			},
+		},
+		"structs with replaced implicit pads shouldn't be relayouted": {
+			o: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 1, Align: 1}, {Name: "b", Size: 8, Align: 8}},
+			},
+			r: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 1, Align: 1}, {Name: "_", Size: 7, Align: 1}, {Name: "b", Size: 8, Align: 8}},
+			},
+		},
+		"structs with swapped same size fields should be relayouted": {
+			o: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 8, Align: 8}, {Name: "b", Size: 8, Align: 8}},
+			},
+			r: gopium.Struct{
+				Fields: []gopium.Field{{Name: "b", Size: 8, Align: 8}, {Name: "a", Size: 8, Align: 8}},
+			},
+			res: true,
+		},
+		"structs with leading pad should be relayouted": {
+			o: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 8, Align: 8}, {Name: "b", Size: 8, Align: 8}},
+			},
+			r: gopium.Struct{
+				Fields: []gopium.Field{{Name: "_", Size: 8, Align: 1}, {Name: "a", Size: 8, Align: 8}, {Name: "b", Size: 8, Align: 8}},
+			},
+			res: true,
+		},
+		"structs with trailing pad should be relayouted": {
+			o: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 8, Align: 8}, {Name: "b", Size: 8, Align: 8}},
+			},
+			r: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 8, Align: 8}, {Name: "b", Size: 8, Align: 8}, {Name: "_", Size: 48, Align: 1}},
+			},
+			res: true,
+		},
+		"structs with filtered pads should be relayouted": {
+			o: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 8, Align: 8}, {Name: "_", Size: 8, Align: 1}, {Name: "b", Size: 8, Align: 8}},
+			},
+			r: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 8, Align: 8}, {Name: "b", Size: 8, Align: 8}},
+			},
+			res: true,
+		},
+		"structs with removed fields should be relayouted": {
+			o: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 8, Align: 8}, {Name: "b", Size: 8, Align: 8}},
+			},
+			r: gopium.Struct{
+				Fields: []gopium.Field{{Name: "a", Size: 8, Align: 8}, {Name: "c", Size: 8, Align: 8}},
+			},
+			res: true,
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// exec
+			res := relayouts(tcase.o, tcase.r)
+			// check
+			if !reflect.DeepEqual(res, tcase.res) {
+				t.Errorf("actual %v doesn't equal to expected %v", res, tcase.res)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package walkers
 
 import (
 	"context"
+	"go/types"
 	"regexp"
 
 	"github.com/1pkg/gopium/collections"
@@ -32,6 +33,16 @@ var (
 		persister: astutil.Package{},
 		writer:    fmtio.Origin{Writter: fmtio.Files{Ext: fmtio.GOPIUM}},
 	}
+	soastd = wast{
+		apply:     astutil.SOA,
+		persister: astutil.Package{},
+		writer:    fmtio.Origin{Writter: fmtio.Stdout{}},
+	}
+	soago = wast{
+		apply:     astutil.SOA,
+		persister: astutil.Package{},
+		writer:    fmtio.Origin{Writter: fmtio.Files{Ext: fmtio.SOA}},
+	}
 )
 
 // wast defines packages walker ast sync implementation
@@ -42,10 +53,11 @@ type wast struct {
 	exposer   gopium.Exposer        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	printer   gopium.Printer        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	apply     gopium.Apply          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	profile   gopium.Profile        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	deep      bool                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	bref      bool                  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_         [38]byte              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 88 bytes; - 🌺 gopium @1pkg
+	_         [22]byte              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 104 bytes; - 🌺 gopium @1pkg
 
 // With erich wast walker with external visiting parameters
 // parser, exposer, printer instances and additional visiting flags
@@ -58,6 +70,12 @@ func (w wast) With(xp gopium.Parser, exp gopium.Exposer, p gopium.Printer, deep
 	return w
 }
 
+// Profile erich wast walker with profile instance
+func (w wast) Profile(prof gopium.Profile) wast {
+	w.profile = prof
+	return w
+}
+
 // Visit wast implementation uses visit function helper
 // to go through all structs decls inside the package
 // and applies strategy to them to get results,
@@ -65,15 +83,19 @@ func (w wast) With(xp gopium.Parser, exp gopium.Exposer, p gopium.Printer, deep
 func (w wast) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
 	// use parser to parse types pkg data
 	// we don't care about fset
+	// but types pkg is used by ast apply
 	pkg, loc, err := w.parser.ParseTypes(ctx)
 	if err != nil {
 		return err
 	}
+	// prepare lazy package usages
+	// that are collected only on demand
+	lus := lazyusages(w.parser, pkg, w.profile)
 	// create govisit func
 	// using visit helper
 	// and run it on pkg scope
 	ch := make(appliedCh)
-	gvisit := with(w.exposer, loc, w.bref).
+	gvisit := with(w.exposer, loc, lus, w.bref).
 		visit(regex, stg, ch, w.deep)
 	// prepare separate cancelation
 	// context for visiting
@@ -95,12 +117,12 @@ func (w wast) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strate
 	}
 	// run sync write
 	// with collected strategies results
-	return w.write(gctx, h)
+	return w.write(gctx, pkg, h)
 }
 
 // write wast helps to sync and persist
 // strategies results to ast files
-func (w wast) write(ctx context.Context, h collections.Hierarchic) error {
+func (w wast) write(ctx context.Context, tpkg *types.Package, h collections.Hierarchic) error {
 	// skip empty writes
 	if h.Len() == 0 {
 		return nil
@@ -114,7 +136,7 @@ func (w wast) write(ctx context.Context, h collections.Hierarchic) error {
 	// to update ast.Package
 	// in case any error happened
 	// just return error back
-	pkg, err = w.apply(ctx, pkg, loc, h)
+	pkg, err = w.apply(ctx, pkg, tpkg, loc, h)
 	if err != nil {
 		return err
 	}
@@ -16,6 +16,11 @@ var (
 		fmt:    fmtio.SizeAlignMdt,
 		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.MD},
 	}
+	saifilemdt = wdiff{
+		fmt:        fmtio.SizeAlignImportanceMdt,
+		writer:     fmtio.File{Name: gopium.NAME, Ext: fmtio.MD},
+		importance: true,
+	}
 	ffilehtml = wdiff{
 		fmt:    fmtio.FieldsHtmlt,
 		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.HTML},
@@ -24,14 +29,16 @@ var (
 
 // wdiff defines packages walker difference implementation
 type wdiff struct {
-	writer  gopium.Writer     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	parser  gopium.TypeParser `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	exposer gopium.Exposer    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	fmt     gopium.Diff       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	deep    bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	bref    bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_       [6]byte           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 56 bytes; - 🌺 gopium @1pkg
+	writer     gopium.Writer     `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	parser     gopium.TypeParser `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	exposer    gopium.Exposer    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	fmt        gopium.Diff       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	profile    gopium.Profile    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deep       bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bref       bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	importance bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_          [53]byte          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 72 bytes; - 🌺 gopium @1pkg
 
 // With erich wast walker with external visiting parameters
 // parser, exposer instances and additional visiting flags
@@ -43,6 +50,12 @@ func (w wdiff) With(p gopium.TypeParser, exp gopium.Exposer, deep bool, bref boo
 	return w
 }
 
+// Profile erich wdiff walker with profile instance
+func (w wdiff) Profile(prof gopium.Profile) wdiff {
+	w.profile = prof
+	return w
+}
+
 // Visit wdiff implementation uses visit function helper
 // to go through all structs decls inside the package
 // and applies strategy to them to get results,
@@ -55,11 +68,26 @@ func (w wdiff) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strat
 	if err != nil {
 		return err
 	}
+	// prepare lazy package usages
+	// that are collected only on demand
+	lus := lazyusages(w.parser, pkg, w.profile)
+	// importance diff formatters require
+	// structs allocation sites importances
+	// in case any error happened
+	// just return error back
+	var ims importances
+	if w.importance {
+		us, err := lus.usages(ctx)
+		if err != nil {
+			return err
+		}
+		ims = us.ims
+	}
 	// create govisit func
 	// using gopium.Visit helper
 	// and run it on pkg scope
 	ch := make(appliedCh)
-	gvisit := with(w.exposer, loc, w.bref).
+	gvisit := with(w.exposer, loc, lus, w.bref).
 		visit(regex, stg, ch, w.deep)
 	// prepare separate cancelation
 	// context for visiting
@@ -76,6 +104,10 @@ func (w wdiff) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strat
 		if applied.Err != nil {
 			return applied.Err
 		}
+		// note structs with their
+		// allocation sites importances
+		applied.O.Importance = ims[applied.ID]
+		applied.R.Importance = ims[applied.ID]
 		// push structs to storages
 		ho.Push(applied.ID, applied.Loc, applied.O)
 		hr.Push(applied.ID, applied.Loc, applied.R)
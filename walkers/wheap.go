@@ -0,0 +1,213 @@
+package walkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/1pkg/gopium/collections"
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+)
+
+// list of wheap presets
+var (
+	heapfilejson = wheap{
+		fmt:    fmtio.Jsonb,
+		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.JSON},
+	}
+	heapfilexml = wheap{
+		fmt:    fmtio.Xmlb,
+		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.XML},
+	}
+	heapfilecsv = wheap{
+		fmt:    fmtio.Csvb(fmtio.Buffer()),
+		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.CSV},
+	}
+	heapfilemdt = wheap{
+		fmt:    fmtio.Mdtb,
+		writer: fmtio.File{Name: gopium.NAME, Ext: fmtio.MD},
+	}
+)
+
+// wheap defines packages walker heap report implementation
+type wheap struct {
+	writer  gopium.Writer  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	parser  gopium.Parser  `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	exposer gopium.Exposer `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	fmt     gopium.Bytes   `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	profile gopium.Profile `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	deep    bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	bref    bool           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_       [54]byte       `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 72 bytes; - 🌺 gopium @1pkg
+
+// heapsaving defines single struct
+// heap saving data transfer object
+type heapsaving struct {
+	id     string        `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	st     gopium.Struct `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	saving int64         `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	_      [16]byte      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 88 bytes; - 🌺 gopium @1pkg
+
+// With erich wheap walker with external visiting parameters
+// parser, exposer, profile instances and additional visiting flags
+func (w wheap) With(p gopium.Parser, exp gopium.Exposer, prof gopium.Profile, deep bool, bref bool) wheap {
+	w.parser = p
+	w.exposer = exp
+	w.profile = prof
+	w.deep = deep
+	w.bref = bref
+	return w
+}
+
+// Visit wheap implementation uses visit function helper
+// to go through all structs decls inside the package
+// and applies strategy to them to get results,
+// then estimates heap bytes saved by strategies results
+// from structs allocations weighted by heap profile,
+// ranks strategies results by the savings
+// and uses bytes formatter to format them
+// and use writer to write results to output
+func (w wheap) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strategy) error {
+	// check that heap profile has been provided
+	// in case any error happened
+	// just return error back
+	if err := heapprof(w.profile); err != nil {
+		return err
+	}
+	// use parser to parse types pkg data
+	// we don't care about fset
+	pkg, loc, err := w.parser.ParseTypes(ctx)
+	if err != nil {
+		return err
+	}
+	// collect all package usages
+	// as structs allocations are always required
+	// in case any error happened
+	// just return error back
+	lus := lazyusages(w.parser, pkg, w.profile)
+	us, err := lus.usages(ctx)
+	if err != nil {
+		return err
+	}
+	// create govisit func
+	// using gopium.Visit helper
+	// and run it on pkg scope
+	ch := make(appliedCh)
+	gvisit := with(w.exposer, loc, lus, w.bref).
+		visit(regex, stg, ch, w.deep)
+	// prepare separate cancelation
+	// context for visiting
+	gctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// run visiting in separate goroutine
+	go gvisit(gctx, pkg.Scope())
+	// prepare struct storage and savings
+	h := collections.NewHierarchic("")
+	var savings []heapsaving
+	for applied := range ch {
+		// in case any error happened
+		// just return error back
+		// it auto cancels context
+		if applied.Err != nil {
+			return applied.Err
+		}
+		// push struct to storage
+		// and collect its heap saving
+		h.Push(applied.ID, applied.Loc, applied.R)
+		savings = append(savings, hsaving(applied, us.als[applied.ID]))
+	}
+	// run sync write
+	// with collected savings
+	return w.write(gctx, h, savings)
+}
+
+// write wheap helps to apply formatter
+// to format ranked strategies results and writer
+// to write result to output
+func (w wheap) write(ctx context.Context, h collections.Hierarchic, savings []heapsaving) error {
+	// skip empty writes
+	if h.Len() == 0 {
+		return nil
+	}
+	// rank structs by heap savings
+	sort.SliceStable(savings, func(i, j int) bool {
+		if savings[i].saving != savings[j].saving {
+			return savings[i].saving > savings[j].saving
+		}
+		return savings[i].id < savings[j].id
+	})
+	sts := make([]gopium.Struct, 0, len(savings))
+	for _, s := range savings {
+		sts = append(sts, s.st)
+	}
+	// apply formatter
+	buf, err := w.fmt(sts)
+	// in case any error happened
+	// in formatter return error back
+	if err != nil {
+		return err
+	}
+	// generate writer
+	loc := filepath.Join(h.Rcat(), "gopium")
+	writer, err := w.writer.Generate(loc)
+	if err != nil {
+		return err
+	}
+	// write results and close writer
+	// in case any error happened
+	// in writer return error
+	if _, err := writer.Write(buf); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// heapprof checks that provided profile
+// is heap profile with either allocated
+// or inuse space sample type
+func heapprof(prof gopium.Profile) error {
+	sprof, ok := prof.(gopium.SampleProfile)
+	if !ok {
+		return errors.New("heap walkers require heap profile")
+	}
+	switch stype := sprof.SampleType(); stype {
+	case "alloc_space", "inuse_space":
+		return nil
+	default:
+		return fmt.Errorf("heap walkers require heap profile with %q or %q sample type, got %q", "alloc_space", "inuse_space", stype)
+	}
+}
+
+// hsaving estimates heap saving of applied strategy result
+// from struct heap allocations bytes, instances number
+// is estimated as heap bytes divided by original struct size
+// and saving is instances number multiplied by
+// difference of original and result structs sizes,
+// result struct is noted with heap saving doc
+func hsaving(a applied, heap int64) heapsaving {
+	osize, _, _ := collections.SizeAlignPtr(a.O)
+	rsize, _, _ := collections.SizeAlignPtr(a.R)
+	var instances int64
+	if osize > 0 {
+		instances = heap / osize
+	}
+	saving := instances * (osize - rsize)
+	// note result struct with heap saving doc
+	st := collections.CopyStruct(a.R)
+	note := fmt.Sprintf(
+		"// struct heap size: %d bytes; struct heap instances: %d; struct instance saving: %d bytes; struct heap saving: %d bytes; - %s",
+		heap,
+		instances,
+		osize-rsize,
+		saving,
+		gopium.STAMP,
+	)
+	st.Doc = append(st.Doc, note)
+	return heapsaving{id: a.ID, st: st, saving: saving}
+}
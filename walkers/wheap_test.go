@@ -0,0 +1,229 @@
+package walkers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/1pkg/gopium/fmtio"
+	"github.com/1pkg/gopium/gopium"
+	"github.com/1pkg/gopium/strategies"
+	"github.com/1pkg/gopium/tests/data"
+	"github.com/1pkg/gopium/tests/mocks"
+	"github.com/1pkg/gopium/typepkg"
+)
+
+func TestWheap(t *testing.T) {
+	// prepare
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b := strategies.Builder{}
+	pck, err := b.Build(strategies.Pack)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	m, err := typepkg.NewMavenGoTypes("gc", "amd64", 64, 64, 64)
+	if !reflect.DeepEqual(err, nil) {
+		t.Fatalf("actual %v doesn't equal to %v", err, nil)
+	}
+	// names formatter keeps only
+	// structs names and docs
+	names := func(sts []gopium.Struct) ([]byte, error) {
+		type named struct {
+			Name string
+			Doc  []string
+		}
+		nsts := make([]named, 0, len(sts))
+		for _, st := range sts {
+			nsts = append(nsts, named{Name: st.Name, Doc: st.Doc})
+		}
+		return json.MarshalIndent(nsts, "", "\t")
+	}
+	prof := fmtio.Pprof{
+		Lines: map[string]map[int]int64{
+			"heap/file.go": {
+				24: 100,
+				28: 200,
+				32: 400,
+				36: 60,
+				40: 70,
+			},
+		},
+		Sample: "alloc_space",
+	}
+	table := map[string]struct {
+		ctx  context.Context
+		r    *regexp.Regexp
+		p    gopium.Parser
+		prof gopium.Profile
+		fmt  gopium.Bytes
+		w    gopium.Writer
+		stg  gopium.Strategy
+		sts  map[string][]byte
+		err  error
+	}{
+		"empty pkg should visit nothing": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`.*`),
+			p:    data.NewParser("empty"),
+			prof: prof,
+			fmt:  names,
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			sts:  map[string][]byte{},
+		},
+		"heap pkg should visit structs ranked by heap savings": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`.*`),
+			p:    data.NewParser("heap"),
+			prof: prof,
+			fmt:  names,
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			sts: map[string][]byte{
+				"tests_data_heap_gopium": []byte(`
+[
+	{
+		"Name": "Small",
+		"Doc": [
+			"// struct heap size: 330 bytes; struct heap instances: 13; struct instance saving: 8 bytes; struct heap saving: 104 bytes; - 🌺 gopium @1pkg"
+		]
+	},
+	{
+		"Name": "Large",
+		"Doc": [
+			"// struct heap size: 430 bytes; struct heap instances: 10; struct instance saving: 8 bytes; struct heap saving: 80 bytes; - 🌺 gopium @1pkg"
+		]
+	}
+]
+`),
+			},
+		},
+		"heap pkg should visit structs without heap savings on empty profile": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`.*`),
+			p:    data.NewParser("heap"),
+			prof: fmtio.Pprof{Sample: "inuse_space"},
+			fmt:  names,
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			sts: map[string][]byte{
+				"tests_data_heap_gopium": []byte(`
+[
+	{
+		"Name": "Large",
+		"Doc": [
+			"// struct heap size: 0 bytes; struct heap instances: 0; struct instance saving: 8 bytes; struct heap saving: 0 bytes; - 🌺 gopium @1pkg"
+		]
+	},
+	{
+		"Name": "Small",
+		"Doc": [
+			"// struct heap size: 0 bytes; struct heap instances: 0; struct instance saving: 8 bytes; struct heap saving: 0 bytes; - 🌺 gopium @1pkg"
+		]
+	}
+]
+`),
+			},
+		},
+		"heap pkg should visit nothing without profile": {
+			ctx: context.Background(),
+			r:   regexp.MustCompile(`.*`),
+			p:   data.NewParser("heap"),
+			fmt: names,
+			w:   data.Writer{Writer: &mocks.Writer{}},
+			stg: pck,
+			err: errors.New("heap walkers require heap profile"),
+		},
+		"heap pkg should visit nothing on non heap profile": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`.*`),
+			p:    data.NewParser("heap"),
+			prof: fmtio.Pprof{Sample: "cpu"},
+			fmt:  names,
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			err:  errors.New(`heap walkers require heap profile with "alloc_space" or "inuse_space" sample type, got "cpu"`),
+		},
+		"heap pkg should visit nothing on canceled context": {
+			ctx:  cctx,
+			r:    regexp.MustCompile(`.*`),
+			p:    data.NewParser("heap"),
+			prof: prof,
+			fmt:  names,
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			err:  context.Canceled,
+		},
+		"heap pkg should visit nothing on type parser error": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`.*`),
+			p:    mocks.Parser{Typeserr: errors.New("test-1")},
+			prof: prof,
+			fmt:  names,
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			err:  errors.New("test-1"),
+		},
+		"heap pkg should visit nothing on formatter error": {
+			ctx:  context.Background(),
+			r:    regexp.MustCompile(`.*`),
+			p:    data.NewParser("heap"),
+			prof: prof,
+			fmt:  mocks.Bytes{Err: errors.New("test-2")}.Bytes,
+			w:    data.Writer{Writer: &mocks.Writer{}},
+			stg:  pck,
+			err:  errors.New("test-2"),
+		},
+	}
+	for name, tcase := range table {
+		t.Run(name, func(t *testing.T) {
+			// prepare
+			wheap := wheap{
+				fmt:    tcase.fmt,
+				writer: tcase.w,
+			}.With(tcase.p, m, tcase.prof, true, true)
+			// exec
+			err := wheap.Visit(tcase.ctx, tcase.r, tcase.stg)
+			// check
+			if fmt.Sprintf("%v", err) != fmt.Sprintf("%v", tcase.err) {
+				t.Errorf("actual %v doesn't equal to expected %v", err, tcase.err)
+			}
+			// process checks only on success
+			if tcase.err == nil {
+				w := (tcase.w.(data.Writer)).Writer.(*mocks.Writer)
+				for id, rwc := range w.RWCs {
+					// check all struct
+					// against bytes map
+					if st, ok := tcase.sts[id]; ok {
+						// read rwc to buffer
+						var buf bytes.Buffer
+						_, err := buf.ReadFrom(rwc)
+						if !reflect.DeepEqual(err, nil) {
+							t.Errorf("actual %v doesn't equal to expected %v", err, nil)
+						}
+						// format actual and expected identically
+						actual := strings.Trim(buf.String(), "\n")
+						expected := strings.Trim(string(st), "\n")
+						if !reflect.DeepEqual(actual, expected) {
+							t.Errorf("id %v actual %v doesn't equal to expected %v", id, actual, expected)
+						}
+						delete(tcase.sts, id)
+					} else {
+						t.Errorf("actual %v doesn't equal to expected %v", id, "")
+					}
+				}
+				// check that map has been drained
+				if !reflect.DeepEqual(tcase.sts, map[string][]byte{}) {
+					t.Errorf("actual %v doesn't equal to expected %v", tcase.sts, map[string][]byte{})
+				}
+			}
+		})
+	}
+}
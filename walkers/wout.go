@@ -36,10 +36,11 @@ type wout struct {
 	parser  gopium.TypeParser `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	exposer gopium.Exposer    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	fmt     gopium.Bytes      `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+	profile gopium.Profile    `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	deep    bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
 	bref    bool              `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-	_       [6]byte           `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
-} // struct size: 64 bytes; struct align: 8 bytes; struct aligned size: 64 bytes; struct ptr scan size: 56 bytes; - 🌺 gopium @1pkg
+	_       [54]byte          `gopium:"filter_pads,memory_pack,cache_rounding_cpu_l1_discrete,struct_annotate_comment,add_tag_group_force"`
+} // struct size: 128 bytes; struct align: 8 bytes; struct aligned size: 128 bytes; struct ptr scan size: 72 bytes; - 🌺 gopium @1pkg
 
 // With erich wast walker with external visiting parameters
 // parser, exposer instances and additional visiting flags
@@ -51,6 +52,12 @@ func (w wout) With(p gopium.TypeParser, exp gopium.Exposer, deep bool, bref bool
 	return w
 }
 
+// Profile erich wout walker with profile instance
+func (w wout) Profile(prof gopium.Profile) wout {
+	w.profile = prof
+	return w
+}
+
 // Visit wout implementation uses visit function helper
 // to go through all structs decls inside the package
 // and applies strategy to them to get results,
@@ -63,11 +70,14 @@ func (w wout) Visit(ctx context.Context, regex *regexp.Regexp, stg gopium.Strate
 	if err != nil {
 		return err
 	}
+	// prepare lazy package usages
+	// that are collected only on demand
+	lus := lazyusages(w.parser, pkg, w.profile)
 	// create govisit func
 	// using gopium.Visit helper
 	// and run it on pkg scope
 	ch := make(appliedCh)
-	gvisit := with(w.exposer, loc, w.bref).
+	gvisit := with(w.exposer, loc, lus, w.bref).
 		visit(regex, stg, ch, w.deep)
 	// prepare separate cancelation
 	// context for visiting
@@ -37,7 +37,7 @@ func TestWout(t *testing.T) {
 	table := map[string]struct {
 		ctx  context.Context
 		r    *regexp.Regexp
-		p    gopium.TypeParser
+		p    gopium.Parser
 		fmt  gopium.Bytes
 		w    gopium.Writer
 		stg  gopium.Strategy